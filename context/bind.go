@@ -0,0 +1,213 @@
+package context
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const BindTag = "cmd"
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+	timeType            = reflect.TypeOf(time.Time{})
+	urlType             = reflect.TypeOf((*url.URL)(nil))
+)
+
+func (ctx *Context) Bind(target any) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.New("bind target must be a non-nil pointer to struct")
+	}
+	v = v.Elem()
+	t := v.Type()
+
+	errs := make([]error, 0)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, exist := BindTagName(field.Tag)
+		if !exist {
+			continue
+		}
+		if !field.IsExported() {
+			errs = append(errs, fmt.Errorf("field %s with %s tag must be exported", field.Name, BindTag))
+			continue
+		}
+
+		values, exist := ctx.flagValues[name]
+		if !exist {
+			continue
+		}
+
+		if parsed, exist := ctx.values[name]; exist && parsed != nil && reflect.TypeOf(parsed).AssignableTo(field.Type) {
+			v.Field(i).Set(reflect.ValueOf(parsed))
+			continue
+		}
+
+		if err := bindValues(v.Field(i), values); err != nil {
+			errs = append(errs, fmt.Errorf("flag %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func BindTagName(tag reflect.StructTag) (string, bool) {
+	value, exist := tag.Lookup(BindTag)
+	if !exist {
+		return "", false
+	}
+	name := strings.TrimSpace(strings.SplitN(value, ",", 2)[0])
+	if name == "" || name == "-" {
+		return "", false
+	}
+	return name, true
+}
+
+func IsBindable(t reflect.Type) bool {
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) || t == durationType || t == urlType {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return !isCollection(t.Elem()) && IsBindable(t.Elem())
+	case reflect.Map:
+		return t.Key().Kind() == reflect.String && !isCollection(t.Elem()) && IsBindable(t.Elem())
+	}
+	return false
+}
+
+func isCollection(t reflect.Type) bool {
+	if t == urlType || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return false
+	}
+	return t.Kind() == reflect.Slice || t.Kind() == reflect.Map
+}
+
+func bindValues(field reflect.Value, values []string) error {
+	if !isCollection(field.Type()) {
+		return bindValue(field, values[len(values)-1])
+	}
+
+	if field.Kind() == reflect.Map {
+		return bindMap(field, values)
+	}
+	return bindSlice(field, values)
+}
+
+func bindValue(field reflect.Value, value string) error {
+	if field.Type() == timeType {
+		parsed, err := ParseTimestamp(value)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(parsed))
+		return nil
+	}
+
+	if field.Type() == urlType {
+		parsed, err := ParseURL(value)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(parsed))
+		return nil
+	}
+
+	if unmarshaler, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(value))
+	}
+
+	if field.Type() == durationType {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(parsed))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.Bool:
+		if value == "" {
+			field.SetBool(true)
+			return nil
+		}
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(parsed)
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := ParseInt(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := ParseUint(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(parsed)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+func bindSlice(field reflect.Value, values []string) error {
+	parts := values
+	if len(values) == 1 {
+		parts = make([]string, 0)
+		if values[0] != "" {
+			parts = strings.Split(values[0], ",")
+		}
+	}
+
+	slice := reflect.MakeSlice(field.Type(), len(parts), len(parts))
+	for i, part := range parts {
+		if err := bindValue(slice.Index(i), strings.TrimSpace(part)); err != nil {
+			return fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+	field.Set(slice)
+	return nil
+}
+
+func bindMap(field reflect.Value, values []string) error {
+	result := reflect.MakeMapWithSize(field.Type(), len(values))
+	for _, value := range values {
+		key, val, err := ParseKeyValue(value)
+		if err != nil {
+			return err
+		}
+
+		elem := reflect.New(field.Type().Elem()).Elem()
+		if err := bindValue(elem, val); err != nil {
+			return fmt.Errorf("key %s: %w", key, err)
+		}
+		result.SetMapIndex(reflect.ValueOf(key).Convert(field.Type().Key()), elem)
+	}
+	field.Set(result)
+	return nil
+}
@@ -0,0 +1,121 @@
+package context
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	prs "github.com/DilemaFixer/Cmd/parser"
+)
+
+type level int
+
+func (l *level) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return errors.New("unknown level")
+	}
+	return nil
+}
+
+type bindOptions struct {
+	Name     string        `cmd:"name"`
+	Count    int           `cmd:"count"`
+	Small    int8          `cmd:"small"`
+	Big      uint64        `cmd:"big"`
+	Rate     float32       `cmd:"rate"`
+	Enabled  bool          `cmd:"enabled"`
+	Quiet    bool          `cmd:"quiet"`
+	Tags     []string      `cmd:"tags"`
+	Ports    []int         `cmd:"ports"`
+	Timeout  time.Duration `cmd:"timeout"`
+	Level    level         `cmd:"level"`
+	IP       net.IP        `cmd:"ip"`
+	Missing  string        `cmd:"missing"`
+	Untagged string
+}
+
+func makeBindContext(flags ...prs.InputFlag) *Context {
+	return NewContext(&prs.ParsedInput{Command: "bind", InputFlags: flags})
+}
+
+func TestBind_WithAllSupportedKinds_FillsStruct(t *testing.T) {
+	ctx := makeBindContext(
+		prs.InputFlag{Name: "name", Value: "test"},
+		prs.InputFlag{Name: "count", Value: "10"},
+		prs.InputFlag{Name: "small", Value: "-8"},
+		prs.InputFlag{Name: "big", Value: "18446744073709551615"},
+		prs.InputFlag{Name: "rate", Value: "0.5"},
+		prs.InputFlag{Name: "enabled", Value: ""},
+		prs.InputFlag{Name: "quiet", Value: "false"},
+		prs.InputFlag{Name: "tags", Value: "a, b,c"},
+		prs.InputFlag{Name: "ports", Value: "80,443"},
+		prs.InputFlag{Name: "timeout", Value: "1m30s"},
+		prs.InputFlag{Name: "level", Value: "high"},
+		prs.InputFlag{Name: "ip", Value: "10.0.0.1"},
+		prs.InputFlag{Name: "Untagged", Value: "ignored"},
+	)
+
+	var opts bindOptions
+	opts.Missing = "keep"
+	if err := ctx.Bind(&opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := bindOptions{
+		Name:    "test",
+		Count:   10,
+		Small:   -8,
+		Big:     18446744073709551615,
+		Rate:    0.5,
+		Enabled: true,
+		Quiet:   false,
+		Tags:    []string{"a", "b", "c"},
+		Ports:   []int{80, 443},
+		Timeout: 90 * time.Second,
+		Level:   2,
+		IP:      net.ParseIP("10.0.0.1"),
+		Missing: "keep",
+	}
+	if !reflect.DeepEqual(opts, want) {
+		t.Fatalf("expected %+v, got %+v", want, opts)
+	}
+}
+
+func TestBind_WithSeveralInvalidValues_ReportsAllErrors(t *testing.T) {
+	ctx := makeBindContext(
+		prs.InputFlag{Name: "count", Value: "ten"},
+		prs.InputFlag{Name: "small", Value: "300"},
+		prs.InputFlag{Name: "ports", Value: "80,http"},
+		prs.InputFlag{Name: "timeout", Value: "soon"},
+		prs.InputFlag{Name: "level", Value: "medium"},
+	)
+
+	var opts bindOptions
+	err := ctx.Bind(&opts)
+	if err == nil {
+		t.Fatalf("expected bind error")
+	}
+	for _, name := range []string{"count", "small", "ports", "timeout", "level"} {
+		if !strings.Contains(err.Error(), "flag "+name+":") {
+			t.Errorf("error does not mention flag %s: %v", name, err)
+		}
+	}
+}
+
+func TestBind_WithNonPointer_ReturnsError(t *testing.T) {
+	ctx := makeBindContext()
+	if err := ctx.Bind(bindOptions{}); err == nil {
+		t.Fatalf("expected error for non-pointer target")
+	}
+	if err := ctx.Bind((*bindOptions)(nil)); err == nil {
+		t.Fatalf("expected error for nil target")
+	}
+}
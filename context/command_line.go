@@ -0,0 +1,28 @@
+package context
+
+import prs "github.com/DilemaFixer/Cmd/parser"
+
+func (ctx Context) Args() []string {
+	return ctx.args(false)
+}
+
+func (ctx Context) String() string {
+	return prs.JoinArgs(ctx.args(true))
+}
+
+func (ctx Context) args(masked bool) []string {
+	args := append([]string{ctx.command}, ctx.subcommands...)
+	for _, flag := range ctx.orderedFlags() {
+		for _, value := range ctx.flagValues[flag] {
+			if value == "" {
+				args = append(args, "--"+flag)
+				continue
+			}
+			if masked && ctx.secrets[flag] {
+				value = SecretMask
+			}
+			args = append(args, "--"+flag+"="+value)
+		}
+	}
+	return args
+}
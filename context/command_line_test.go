@@ -0,0 +1,29 @@
+package context
+
+import (
+	"reflect"
+	"testing"
+
+	prs "github.com/DilemaFixer/Cmd/parser"
+)
+
+func TestContext_Args_RoundTripsThroughParseInput(t *testing.T) {
+	ctx := makeSecretContext()
+	ctx.SetFlagValues("tag", []string{"a b", "c"})
+
+	want := []string{"db", "connect", "--user=admin", "--password=hunter2", "--ssl", "--tag=a b", "--tag=c"}
+	if got := ctx.Args(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	parsed, err := prs.ParseInput(prs.JoinArgs(ctx.Args()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := NewContext(parsed).Args(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %q after round trip, got %q", want, got)
+	}
+	if got := ctx.String(); got != "db connect --user=admin --password='******' --ssl --tag='a b' --tag=c" {
+		t.Fatalf("unexpected string %s", got)
+	}
+}
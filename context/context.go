@@ -3,31 +3,44 @@ package context
 import (
 	"errors"
 	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	prs "github.com/DilemaFixer/Cmd/parser"
 )
 
 type Context struct {
 	command     string
-	subcommands map[string]struct{}
+	subcommands []string
 	flags       map[string]string
+	flagValues  map[string][]string
+	flagOrder   map[string]int
+	values      map[string]any
+	secrets     map[string]bool
+	workDir     string
+	stdin       io.Reader
 }
 
 func NewContext(input *prs.ParsedInput) *Context {
 	ctx := &Context{
 		command:     input.Command,
-		subcommands: make(map[string]struct{}),
+		subcommands: make([]string, 0, len(input.Subcommands)),
 		flags:       make(map[string]string),
+		flagValues:  make(map[string][]string),
+		flagOrder:   make(map[string]int),
+		values:      make(map[string]any),
+		secrets:     make(map[string]bool),
 	}
 
-	for _, subcommand := range input.Subcommands {
-		ctx.subcommands[subcommand] = struct{}{}
-	}
+	ctx.subcommands = append(ctx.subcommands, input.Subcommands...)
 
 	for _, flag := range input.InputFlags {
-		ctx.flags[flag.Name] = flag.Value
+		ctx.AddFlag(flag.Name, flag.Value)
 	}
 
 	return ctx
@@ -41,6 +54,112 @@ func (ctx *Context) IsFlagExist(name string) bool {
 	return exists
 }
 
+func (ctx *Context) SetFlag(name, value string) {
+	ctx.SetFlagValues(name, []string{value})
+}
+
+func (ctx *Context) AddFlag(name, value string) {
+	ctx.orderFlag(name)
+	ctx.flags[name] = value
+	ctx.flagValues[name] = append(ctx.flagValues[name], value)
+}
+
+func (ctx *Context) SetFlagValues(name string, values []string) {
+	ctx.orderFlag(name)
+
+	last := ""
+	if len(values) > 0 {
+		last = values[len(values)-1]
+	}
+	ctx.flags[name] = last
+	ctx.flagValues[name] = append([]string(nil), values...)
+}
+
+func (ctx *Context) RenameFlag(from, to string) {
+	values, exists := ctx.flagValues[from]
+	if !exists || from == to {
+		return
+	}
+
+	if _, exists := ctx.flagOrder[to]; !exists {
+		ctx.flagOrder[to] = ctx.flagOrder[from]
+	}
+	ctx.RemoveFlag(from)
+	delete(ctx.flagOrder, from)
+	ctx.SetFlagValues(to, append(ctx.flagValues[to], values...))
+}
+
+func (ctx *Context) ExpandShortFlags(known func(name string) bool) error {
+	kept := make([]string, 0, len(ctx.subcommands))
+	for _, word := range ctx.subcommands {
+		names, _, _ := strings.Cut(strings.TrimPrefix(word, "-"), "=")
+		if !strings.HasPrefix(word, "-") || names == "" || strings.ContainsFunc(names, func(name rune) bool { return !known(string(name)) }) {
+			kept = append(kept, word)
+			continue
+		}
+
+		flags, err := prs.ParseShortFlags(word)
+		if err != nil {
+			return err
+		}
+		for _, flag := range flags {
+			ctx.AddFlag(flag.Name, flag.Value)
+		}
+	}
+	ctx.subcommands = kept
+	return nil
+}
+
+func (ctx *Context) orderFlag(name string) {
+	if _, exists := ctx.flagOrder[name]; exists {
+		return
+	}
+	next := 0
+	for _, order := range ctx.flagOrder {
+		next = max(next, order+1)
+	}
+	ctx.flagOrder[name] = next
+}
+
+func (ctx *Context) RemoveFlag(name string) {
+	delete(ctx.flags, name)
+	delete(ctx.flagValues, name)
+}
+
+func (ctx *Context) GetValues(name string) ([]string, error) {
+	values, exists := ctx.flagValues[name]
+	if !exists {
+		return nil, errors.New("flag not found")
+	}
+	return append([]string(nil), values...), nil
+}
+
+func (ctx *Context) SetValue(name string, value any) {
+	ctx.values[name] = value
+}
+
+func (ctx *Context) GetValue(name string) (any, error) {
+	value, exists := ctx.values[name]
+	if !exists {
+		return nil, errors.New("parsed value not found")
+	}
+	return value, nil
+}
+
+func GetValueAs[T any](ctx Context, name string) (T, error) {
+	var zero T
+	value, err := ctx.GetValue(name)
+	if err != nil {
+		return zero, err
+	}
+
+	typed, ok := value.(T)
+	if !ok {
+		return zero, fmt.Errorf("parsed value has type %T, not %s", value, reflect.TypeFor[T]())
+	}
+	return typed, nil
+}
+
 func (ctx *Context) IsFlagHaveValue(name string) bool {
 	value, exists := ctx.flags[name]
 	return exists && value != ""
@@ -55,7 +174,7 @@ func (ctx *Context) GetValueAsInt32(name string) (int32, error) {
 		return 0, errors.New("flag has empty value")
 	}
 
-	parsed, err := strconv.ParseInt(value, 10, 32)
+	parsed, err := ParseInt(value, 32)
 	if err != nil {
 		return 0, err
 	}
@@ -71,7 +190,7 @@ func (ctx *Context) GetValueAsInt64(name string) (int64, error) {
 		return 0, errors.New("flag has empty value")
 	}
 
-	return strconv.ParseInt(value, 10, 64)
+	return ParseInt(value, 64)
 }
 
 func (ctx *Context) GetValueAsInt(name string) (int, error) {
@@ -83,7 +202,11 @@ func (ctx *Context) GetValueAsInt(name string) (int, error) {
 		return 0, errors.New("flag has empty value")
 	}
 
-	return strconv.Atoi(value)
+	parsed, err := ParseInt(value, strconv.IntSize)
+	if err != nil {
+		return 0, err
+	}
+	return int(parsed), nil
 }
 
 func (ctx *Context) GetValueAsFloat32(name string) (float32, error) {
@@ -114,15 +237,63 @@ func (ctx *Context) GetValueAsFloat64(name string) (float64, error) {
 	return strconv.ParseFloat(value, 64)
 }
 
+func (ctx *Context) GetValueAsDuration(name string) (time.Duration, error) {
+	value, exists := ctx.flags[name]
+	if !exists {
+		return 0, errors.New("flag not found")
+	}
+	if value == "" {
+		return 0, errors.New("flag has empty value")
+	}
+	return time.ParseDuration(value)
+}
+
+func (ctx *Context) GetValueAsTime(name string) (time.Time, error) {
+	value, exists := ctx.flags[name]
+	if !exists {
+		return time.Time{}, errors.New("flag not found")
+	}
+	if value == "" {
+		return time.Time{}, errors.New("flag has empty value")
+	}
+	return ParseTimestamp(value)
+}
+
+func (ctx *Context) GetValueAsByteSize(name string) (int64, error) {
+	value, exists := ctx.flags[name]
+	if !exists {
+		return 0, errors.New("flag not found")
+	}
+	if value == "" {
+		return 0, errors.New("flag has empty value")
+	}
+	return ParseByteSize(value)
+}
+
 func (ctx *Context) GetValueAsBool(name string) bool {
-	_, exists := ctx.flags[name]
+	value, exists := ctx.flags[name]
 	if !exists {
 		return false
 	}
 
+	if enabled, err := strconv.ParseBool(value); err == nil {
+		return enabled
+	}
 	return true
 }
 
+func (ctx *Context) GetValueAsCount(name string) int {
+	count := 0
+	for _, value := range ctx.flagValues[name] {
+		if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+			count += n
+			continue
+		}
+		count++
+	}
+	return count
+}
+
 func (ctx *Context) GetValueAsString(name string) (string, error) {
 	value, exists := ctx.flags[name]
 	if !exists {
@@ -147,22 +318,12 @@ func (ctx *Context) IsCommandEqual(target string) bool {
 }
 
 func (ctx *Context) IsSubcommandExist(target string) bool {
-	_, exist := ctx.subcommands[target]
-	return exist
+	return slices.Contains(ctx.subcommands, target)
 }
 
 func (ctx *Context) GetSubcommandsAsArr() []string {
-	subcommandsArr := make([]string, 0)
-
-	if len(ctx.subcommands) == 0 {
-		return subcommandsArr
-	}
-
-	for subcommand, _ := range ctx.subcommands {
-		subcommandsArr = append(subcommandsArr, subcommand)
-	}
-
-	return subcommandsArr
+	subcommandsArr := make([]string, 0, len(ctx.subcommands))
+	return append(subcommandsArr, ctx.subcommands...)
 }
 
 func (ctx *Context) GetFlagsAsMap() map[string]string {
@@ -178,29 +339,34 @@ func (ctx *Context) GetFlagsAsMap() map[string]string {
 func (ctx *Context) GetFlagsAsArr() []string {
 	flagsArr := make([]string, 0)
 
-	for flag, value := range ctx.flags {
-		flagsArr = append(flagsArr, fmt.Sprintf("%s=%s", flag, value))
+	for _, flag := range ctx.orderedFlags() {
+		flagsArr = append(flagsArr, fmt.Sprintf("%s=%s", flag, ctx.maskedValue(flag)))
 	}
 
 	return flagsArr
 }
 
 func (ctx *Context) GetFlagsKeysAsArr() []string {
-	flagsKeysArr := make([]string, 0)
-
-	for flag, _ := range ctx.flags {
-		flagsKeysArr = append(flagsKeysArr, flag)
-	}
-
-	return flagsKeysArr
+	return ctx.orderedFlags()
 }
 
 func (ctx *Context) GetFlagsValuesAsArr() []string {
 	flagsValuesArr := make([]string, 0)
 
-	for _, value := range ctx.flags {
-		flagsValuesArr = append(flagsValuesArr, value)
+	for _, flag := range ctx.orderedFlags() {
+		flagsValuesArr = append(flagsValuesArr, ctx.maskedValue(flag))
 	}
 
 	return flagsValuesArr
 }
+
+func (ctx *Context) orderedFlags() []string {
+	flags := make([]string, 0, len(ctx.flags))
+	for flag := range ctx.flags {
+		flags = append(flags, flag)
+	}
+	sort.Slice(flags, func(i, j int) bool {
+		return ctx.flagOrder[flags[i]] < ctx.flagOrder[flags[j]]
+	})
+	return flags
+}
@@ -1,7 +1,9 @@
 package context
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	prs "github.com/DilemaFixer/Cmd/parser"
 )
@@ -201,6 +203,63 @@ func TestGetValueAsBool_ParsesCorrectly(t *testing.T) {
 	}
 }
 
+func TestGetValueAsBool_WithExplicitFalse_ReturnsFalse(t *testing.T) {
+	ctx := NewContext(makeParserInput())
+	ctx.SetFlag("cache", "false")
+	if ctx.GetValueAsBool("cache") {
+		t.Fatalf("expected false for explicit false value")
+	}
+	if !ctx.GetValueAsBool("empty") {
+		t.Fatalf("expected true for present flag without value")
+	}
+}
+
+func TestGetValueAsCount_CountsOccurrences(t *testing.T) {
+	ctx := NewContext(&prs.ParsedInput{
+		Command: "run",
+		InputFlags: []prs.InputFlag{
+			{Name: "verbose", Value: ""},
+			{Name: "verbose", Value: ""},
+			{Name: "verbose", Value: "2"},
+		},
+	})
+	if got := ctx.GetValueAsCount("verbose"); got != 4 {
+		t.Fatalf("expected 4, got %d", got)
+	}
+	if got := ctx.GetValueAsCount("missing"); got != 0 {
+		t.Fatalf("expected 0 for missing flag, got %d", got)
+	}
+}
+
+func TestRenameFlag_MergesValuesIntoTarget(t *testing.T) {
+	ctx := NewContext(&prs.ParsedInput{
+		Command: "run",
+		InputFlags: []prs.InputFlag{
+			{Name: "v", Value: ""},
+			{Name: "name", Value: "test"},
+			{Name: "verbose", Value: ""},
+			{Name: "v", Value: ""},
+		},
+	})
+	ctx.RenameFlag("v", "verbose")
+
+	if ctx.IsFlagExist("v") {
+		t.Fatalf("expected short flag to be removed")
+	}
+	if values, _ := ctx.GetValues("verbose"); len(values) != 3 {
+		t.Fatalf("expected 3 values, got %v", values)
+	}
+	if keys := ctx.GetFlagsKeysAsArr(); len(keys) != 2 || keys[0] != "name" || keys[1] != "verbose" {
+		t.Fatalf("unexpected flag order %v", keys)
+	}
+
+	ctx.AddFlag("v", "")
+	ctx.AddFlag("x", "")
+	if keys := ctx.GetFlagsKeysAsArr(); strings.Join(keys, " ") != "name verbose v x" {
+		t.Fatalf("expected flags added after rename to go last, got %v", keys)
+	}
+}
+
 func TestGetValueAsString_ReturnsValue(t *testing.T) {
 	ctx := NewContext(makeParserInput())
 	val, err := ctx.GetValueAsString("name")
@@ -263,3 +322,50 @@ func TestFlagsCollections_ReturnsBrokenFlags(t *testing.T) {
 		t.Fatalf("expected 4 keys in broken input")
 	}
 }
+
+func makeUnitsContext() *Context {
+	return NewContext(&prs.ParsedInput{
+		Command: "units",
+		InputFlags: []prs.InputFlag{
+			{Name: "timeout", Value: "1m30s"},
+			{Name: "since", Value: "2024-05-01"},
+			{Name: "size", Value: "10MiB"},
+			{Name: "bad", Value: "soon"},
+			{Name: "empty", Value: ""},
+		},
+	})
+}
+
+func TestGetValueAsDuration_ParsesCorrectly(t *testing.T) {
+	ctx := makeUnitsContext()
+	if val, err := ctx.GetValueAsDuration("timeout"); err != nil || val != 90*time.Second {
+		t.Fatalf("expected 1m30s, got %s, err=%v", val, err)
+	}
+	if _, err := ctx.GetValueAsDuration("bad"); err == nil {
+		t.Fatalf("expected error for invalid duration")
+	}
+	if _, err := ctx.GetValueAsDuration("empty"); err == nil {
+		t.Fatalf("expected error for empty duration")
+	}
+}
+
+func TestGetValueAsTime_ParsesCorrectly(t *testing.T) {
+	ctx := makeUnitsContext()
+	want := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	if val, err := ctx.GetValueAsTime("since"); err != nil || !val.Equal(want) {
+		t.Fatalf("expected %v, got %v, err=%v", want, val, err)
+	}
+	if _, err := ctx.GetValueAsTime("missing"); err == nil {
+		t.Fatalf("expected error for missing flag")
+	}
+}
+
+func TestGetValueAsByteSize_ParsesCorrectly(t *testing.T) {
+	ctx := makeUnitsContext()
+	if val, err := ctx.GetValueAsByteSize("size"); err != nil || val != 10<<20 {
+		t.Fatalf("expected %d, got %d, err=%v", 10<<20, val, err)
+	}
+	if _, err := ctx.GetValueAsByteSize("bad"); err == nil {
+		t.Fatalf("expected error for invalid size")
+	}
+}
@@ -0,0 +1,94 @@
+package context
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func ParseKeyValue(value string) (string, string, error) {
+	key, val, found := strings.Cut(value, "=")
+	key = strings.TrimSpace(key)
+	if !found || key == "" {
+		return "", "", fmt.Errorf("invalid key=value pair %q", value)
+	}
+	return key, val, nil
+}
+
+func (ctx *Context) GetValueAsStringSlice(name string) ([]string, error) {
+	values, exists := ctx.flagValues[name]
+	if !exists {
+		return nil, errors.New("flag not found")
+	}
+	return append([]string(nil), values...), nil
+}
+
+func (ctx *Context) GetValueAsIntSlice(name string) ([]int, error) {
+	values, exists := ctx.flagValues[name]
+	if !exists {
+		return nil, errors.New("flag not found")
+	}
+
+	result := make([]int, 0, len(values))
+	for _, value := range values {
+		parsed, err := ParseInt(value, strconv.IntSize)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, int(parsed))
+	}
+	return result, nil
+}
+
+func (ctx *Context) GetValueAsFloatSlice(name string) ([]float64, error) {
+	values, exists := ctx.flagValues[name]
+	if !exists {
+		return nil, errors.New("flag not found")
+	}
+
+	result := make([]float64, 0, len(values))
+	for _, value := range values {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, parsed)
+	}
+	return result, nil
+}
+
+func (ctx *Context) GetValueAsStringMap(name string) (map[string]string, error) {
+	keys, err := ctx.GetMapKeys(name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string, len(keys))
+	for _, value := range ctx.flagValues[name] {
+		key, val, _ := ParseKeyValue(value)
+		result[key] = val
+	}
+	return result, nil
+}
+
+func (ctx *Context) GetMapKeys(name string) ([]string, error) {
+	values, exists := ctx.flagValues[name]
+	if !exists {
+		return nil, errors.New("flag not found")
+	}
+
+	keys := make([]string, 0, len(values))
+	seen := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		key, _, err := ParseKeyValue(value)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := seen[key]; !exists {
+			seen[key] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,91 @@
+package context
+
+import (
+	"reflect"
+	"testing"
+
+	prs "github.com/DilemaFixer/Cmd/parser"
+)
+
+func makeRepeatedFlagsContext() *Context {
+	return NewContext(&prs.ParsedInput{
+		Command:     "sync",
+		Subcommands: []string{"remote", "push", "all"},
+		InputFlags: []prs.InputFlag{
+			{Name: "exclude", Value: "*.tmp"},
+			{Name: "port", Value: "80"},
+			{Name: "label", Value: "env=prod"},
+			{Name: "exclude", Value: "*.log"},
+			{Name: "port", Value: "443"},
+			{Name: "label", Value: "team=core"},
+			{Name: "label", Value: "env=stage"},
+		},
+	})
+}
+
+func TestNewContext_RepeatedFlags_KeepsAllValuesInOrder(t *testing.T) {
+	ctx := makeRepeatedFlagsContext()
+
+	if got, _ := ctx.GetValueAsStringSlice("exclude"); !reflect.DeepEqual(got, []string{"*.tmp", "*.log"}) {
+		t.Fatalf("unexpected exclude values %v", got)
+	}
+	if got, _ := ctx.GetValueAsString("exclude"); got != "*.log" {
+		t.Fatalf("expected last value *.log, got %s", got)
+	}
+	if got, err := ctx.GetValueAsIntSlice("port"); err != nil || !reflect.DeepEqual(got, []int{80, 443}) {
+		t.Fatalf("unexpected port values %v, err=%v", got, err)
+	}
+	if _, err := ctx.GetValueAsIntSlice("exclude"); err == nil {
+		t.Fatalf("expected error for non-int values")
+	}
+}
+
+func TestGetValueAsStringMap_LastKeyWinsAndKeysKeepOrder(t *testing.T) {
+	ctx := makeRepeatedFlagsContext()
+
+	got, err := ctx.GetValueAsStringMap("label")
+	if err != nil || !reflect.DeepEqual(got, map[string]string{"env": "stage", "team": "core"}) {
+		t.Fatalf("unexpected labels %v, err=%v", got, err)
+	}
+	if keys, _ := ctx.GetMapKeys("label"); !reflect.DeepEqual(keys, []string{"env", "team"}) {
+		t.Fatalf("unexpected key order %v", keys)
+	}
+	if _, err := ctx.GetValueAsStringMap("port"); err == nil {
+		t.Fatalf("expected error for values without key")
+	}
+}
+
+func TestContext_SubcommandsAndFlagsKeepInputOrder(t *testing.T) {
+	ctx := makeRepeatedFlagsContext()
+
+	if got := ctx.GetSubcommandsAsArr(); !reflect.DeepEqual(got, []string{"remote", "push", "all"}) {
+		t.Fatalf("unexpected subcommands order %v", got)
+	}
+	if got := ctx.GetFlagsKeysAsArr(); !reflect.DeepEqual(got, []string{"exclude", "port", "label"}) {
+		t.Fatalf("unexpected flags order %v", got)
+	}
+
+	ctx.SetFlag("added", "1")
+	if got := ctx.GetFlagsAsArr(); !reflect.DeepEqual(got, []string{"exclude=*.log", "port=443", "label=env=stage", "added=1"}) {
+		t.Fatalf("unexpected flags %v", got)
+	}
+}
+
+func TestBind_RepeatedFlagsIntoSliceAndMap(t *testing.T) {
+	ctx := makeRepeatedFlagsContext()
+
+	var opts struct {
+		Exclude []string          `cmd:"exclude"`
+		Ports   []uint16          `cmd:"port"`
+		Labels  map[string]string `cmd:"label"`
+	}
+	if err := ctx.Bind(&opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(opts.Exclude, []string{"*.tmp", "*.log"}) || !reflect.DeepEqual(opts.Ports, []uint16{80, 443}) {
+		t.Fatalf("unexpected slices %+v", opts)
+	}
+	if !reflect.DeepEqual(opts.Labels, map[string]string{"env": "stage", "team": "core"}) {
+		t.Fatalf("unexpected labels %v", opts.Labels)
+	}
+}
@@ -0,0 +1,102 @@
+package context
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+func ParseURL(value string, hostless ...string) (*url.URL, error) {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme == "" {
+		return nil, fmt.Errorf("url %q has no scheme", value)
+	}
+	if parsed.Host != "" {
+		return parsed, nil
+	}
+	if !slices.ContainsFunc(hostless, func(scheme string) bool { return strings.EqualFold(scheme, parsed.Scheme) }) {
+		return nil, fmt.Errorf("url %q has no host", value)
+	}
+	if parsed.Opaque == "" && parsed.Path == "" {
+		return nil, fmt.Errorf("url %q has no host or path", value)
+	}
+	return parsed, nil
+}
+
+func ParseHostPort(value string) (string, uint16, error) {
+	host, portStr, err := net.SplitHostPort(value)
+	if err != nil {
+		return "", 0, err
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid port %q", portStr)
+	}
+	return host, uint16(port), nil
+}
+
+func (ctx *Context) GetValueAsURL(name string, hostless ...string) (*url.URL, error) {
+	if parsed, ok := ctx.values[name].(*url.URL); ok {
+		return parsed, nil
+	}
+	value, exists := ctx.flags[name]
+	if !exists {
+		return nil, errors.New("flag not found")
+	}
+	if value == "" {
+		return nil, errors.New("flag has empty value")
+	}
+	return ParseURL(value, hostless...)
+}
+
+func (ctx *Context) GetValueAsHostPort(name string) (string, uint16, error) {
+	value, exists := ctx.flags[name]
+	if !exists {
+		return "", 0, errors.New("flag not found")
+	}
+	if value == "" {
+		return "", 0, errors.New("flag has empty value")
+	}
+	return ParseHostPort(value)
+}
+
+func (ctx *Context) GetValueAsAddrPort(name string) (netip.AddrPort, error) {
+	value, exists := ctx.flags[name]
+	if !exists {
+		return netip.AddrPort{}, errors.New("flag not found")
+	}
+	if value == "" {
+		return netip.AddrPort{}, errors.New("flag has empty value")
+	}
+	return netip.ParseAddrPort(value)
+}
+
+func (ctx *Context) GetValueAsIP(name string) (netip.Addr, error) {
+	value, exists := ctx.flags[name]
+	if !exists {
+		return netip.Addr{}, errors.New("flag not found")
+	}
+	if value == "" {
+		return netip.Addr{}, errors.New("flag has empty value")
+	}
+	return netip.ParseAddr(value)
+}
+
+func (ctx *Context) GetValueAsCIDR(name string) (netip.Prefix, error) {
+	value, exists := ctx.flags[name]
+	if !exists {
+		return netip.Prefix{}, errors.New("flag not found")
+	}
+	if value == "" {
+		return netip.Prefix{}, errors.New("flag has empty value")
+	}
+	return netip.ParsePrefix(value)
+}
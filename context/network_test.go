@@ -0,0 +1,86 @@
+package context
+
+import (
+	"net/netip"
+	"testing"
+
+	prs "github.com/DilemaFixer/Cmd/parser"
+)
+
+func makeNetworkContext() *Context {
+	return NewContext(&prs.ParsedInput{
+		Command: "net",
+		InputFlags: []prs.InputFlag{
+			{Name: "url", Value: "https://example.com:8443/api?x=1"},
+			{Name: "listen", Value: "localhost:8080"},
+			{Name: "v6listen", Value: "[::1]:443"},
+			{Name: "ip", Value: "fe80::1"},
+			{Name: "cidr", Value: "10.0.0.0/8"},
+			{Name: "bad", Value: "not valid"},
+		},
+	})
+}
+
+func TestGetValueAsURL_ParsesCorrectly(t *testing.T) {
+	ctx := makeNetworkContext()
+	u, err := ctx.GetValueAsURL("url")
+	if err != nil || u.Scheme != "https" || u.Hostname() != "example.com" || u.Port() != "8443" {
+		t.Fatalf("unexpected url %v, err=%v", u, err)
+	}
+	if _, err := ctx.GetValueAsURL("bad"); err == nil {
+		t.Fatalf("expected error for url without scheme")
+	}
+}
+
+func TestParseURL_WithoutHost_OnlyForAllowedSchemes(t *testing.T) {
+	if _, err := ParseURL("localhost:8080"); err == nil || err.Error() != `url "localhost:8080" has no host` {
+		t.Fatalf("expected missing host error, got %v", err)
+	}
+	if _, err := ParseURL("file:///etc/hosts"); err == nil {
+		t.Fatalf("expected missing host error for file url")
+	}
+	if u, err := ParseURL("file:///etc/hosts", "file"); err != nil || u.Path != "/etc/hosts" {
+		t.Fatalf("unexpected url %v, err=%v", u, err)
+	}
+	if u, err := ParseURL("MAILTO:ops@example.com", "mailto"); err != nil || u.Opaque != "ops@example.com" {
+		t.Fatalf("unexpected url %v, err=%v", u, err)
+	}
+	if _, err := ParseURL("file:", "file"); err == nil {
+		t.Fatalf("expected error for url without host or path")
+	}
+}
+
+func TestGetValueAsHostPort_ParsesCorrectly(t *testing.T) {
+	ctx := makeNetworkContext()
+	if host, port, err := ctx.GetValueAsHostPort("listen"); err != nil || host != "localhost" || port != 8080 {
+		t.Fatalf("expected localhost:8080, got %s:%d, err=%v", host, port, err)
+	}
+	if host, port, err := ctx.GetValueAsHostPort("v6listen"); err != nil || host != "::1" || port != 443 {
+		t.Fatalf("expected [::1]:443, got %s:%d, err=%v", host, port, err)
+	}
+	if _, _, err := ctx.GetValueAsHostPort("ip"); err == nil {
+		t.Fatalf("expected error for address without port")
+	}
+	if _, err := ctx.GetValueAsAddrPort("v6listen"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGetValueAsIPAndCIDR_ParsesCorrectly(t *testing.T) {
+	ctx := makeNetworkContext()
+	if ip, err := ctx.GetValueAsIP("ip"); err != nil || ip != netip.MustParseAddr("fe80::1") {
+		t.Fatalf("unexpected ip %v, err=%v", ip, err)
+	}
+	if prefix, err := ctx.GetValueAsCIDR("cidr"); err != nil || prefix.Bits() != 8 {
+		t.Fatalf("unexpected cidr %v, err=%v", prefix, err)
+	}
+	if _, err := ctx.GetValueAsCIDR("ip"); err == nil {
+		t.Fatalf("expected error for ip without prefix length")
+	}
+}
+
+func TestParseHostPort_WithPortOutOfRange_ReturnsError(t *testing.T) {
+	if _, _, err := ParseHostPort("localhost:70000"); err == nil {
+		t.Fatalf("expected error for port out of range")
+	}
+}
@@ -0,0 +1,124 @@
+package context
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const IntegerFormats = "decimal, 0x hex, 0o octal, 0b binary, _ digit separators"
+
+func ParseInt(value string, bitSize int) (int64, error) {
+	return strconv.ParseInt(normalizeInteger(value), 0, bitSize)
+}
+
+func ParseUint(value string, bitSize int) (uint64, error) {
+	return strconv.ParseUint(normalizeInteger(value), 0, bitSize)
+}
+
+func normalizeInteger(value string) string {
+	value = strings.TrimSpace(value)
+	sign := ""
+	if value != "" && (value[0] == '+' || value[0] == '-') {
+		sign, value = value[:1], value[1:]
+	}
+
+	if len(value) > 1 && value[0] == '0' && strings.ContainsRune("xXoObB", rune(value[1])) {
+		return sign + value
+	}
+	for len(value) > 1 && value[0] == '0' && value[1] >= '0' && value[1] <= '9' {
+		value = value[1:]
+	}
+	return sign + value
+}
+
+func (ctx *Context) integerFlag(name string) (string, error) {
+	value, exists := ctx.flags[name]
+	if !exists {
+		return "", errors.New("flag not found")
+	}
+	if value == "" {
+		return "", errors.New("flag has empty value")
+	}
+	return value, nil
+}
+
+func (ctx *Context) GetValueAsInt8(name string) (int8, error) {
+	value, err := ctx.integerFlag(name)
+	if err != nil {
+		return 0, err
+	}
+	parsed, err := ParseInt(value, 8)
+	if err != nil {
+		return 0, err
+	}
+	return int8(parsed), nil
+}
+
+func (ctx *Context) GetValueAsInt16(name string) (int16, error) {
+	value, err := ctx.integerFlag(name)
+	if err != nil {
+		return 0, err
+	}
+	parsed, err := ParseInt(value, 16)
+	if err != nil {
+		return 0, err
+	}
+	return int16(parsed), nil
+}
+
+func (ctx *Context) GetValueAsUint(name string) (uint, error) {
+	value, err := ctx.integerFlag(name)
+	if err != nil {
+		return 0, err
+	}
+	parsed, err := ParseUint(value, strconv.IntSize)
+	if err != nil {
+		return 0, err
+	}
+	return uint(parsed), nil
+}
+
+func (ctx *Context) GetValueAsUint8(name string) (uint8, error) {
+	value, err := ctx.integerFlag(name)
+	if err != nil {
+		return 0, err
+	}
+	parsed, err := ParseUint(value, 8)
+	if err != nil {
+		return 0, err
+	}
+	return uint8(parsed), nil
+}
+
+func (ctx *Context) GetValueAsUint16(name string) (uint16, error) {
+	value, err := ctx.integerFlag(name)
+	if err != nil {
+		return 0, err
+	}
+	parsed, err := ParseUint(value, 16)
+	if err != nil {
+		return 0, err
+	}
+	return uint16(parsed), nil
+}
+
+func (ctx *Context) GetValueAsUint32(name string) (uint32, error) {
+	value, err := ctx.integerFlag(name)
+	if err != nil {
+		return 0, err
+	}
+	parsed, err := ParseUint(value, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(parsed), nil
+}
+
+func (ctx *Context) GetValueAsUint64(name string) (uint64, error) {
+	value, err := ctx.integerFlag(name)
+	if err != nil {
+		return 0, err
+	}
+	return ParseUint(value, 64)
+}
@@ -0,0 +1,98 @@
+package context
+
+import (
+	"testing"
+
+	prs "github.com/DilemaFixer/Cmd/parser"
+)
+
+func TestParseInt_WithPrefixesAndSeparators_ReturnsValue(t *testing.T) {
+	cases := map[string]int64{
+		"42":        42,
+		"-42":       -42,
+		"+7":        7,
+		"1_000_000": 1000000,
+		"0x1F":      31,
+		"-0x10":     -16,
+		"0o17":      15,
+		"0b1010":    10,
+		"010":       10,
+		"0":         0,
+		"-0":        0,
+	}
+	for input, want := range cases {
+		got, err := ParseInt(input, 64)
+		if err != nil {
+			t.Errorf("ParseInt(%q) unexpected error: %v", input, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseInt(%q) = %d, want %d", input, got, want)
+		}
+	}
+}
+
+func TestParseInt_WithInvalidOrOverflowingValues_ReturnsError(t *testing.T) {
+	cases := []struct {
+		input   string
+		bitSize int
+	}{
+		{"", 64}, {"0x", 64}, {"1__0", 64}, {"_1", 64}, {"12a", 64},
+		{"128", 8}, {"-129", 8}, {"0x8000", 16}, {"2147483648", 32},
+	}
+	for _, tc := range cases {
+		if _, err := ParseInt(tc.input, tc.bitSize); err == nil {
+			t.Errorf("ParseInt(%q, %d) expected error", tc.input, tc.bitSize)
+		}
+	}
+}
+
+func TestParseUint_WithNegativeOrOverflowingValues_ReturnsError(t *testing.T) {
+	for _, input := range []string{"-1", "256", "0x100"} {
+		if _, err := ParseUint(input, 8); err == nil {
+			t.Errorf("ParseUint(%q, 8) expected error", input)
+		}
+	}
+	if got, err := ParseUint("0xFF", 8); err != nil || got != 255 {
+		t.Errorf("ParseUint(0xFF, 8) = %d, %v", got, err)
+	}
+}
+
+func TestSizedIntegerGetters_ValidateWidth(t *testing.T) {
+	ctx := NewContext(&prs.ParsedInput{
+		Command: "run",
+		InputFlags: []prs.InputFlag{
+			{Name: "small", Value: "0x7f"},
+			{Name: "big", Value: "300"},
+			{Name: "negative", Value: "-1"},
+		},
+	})
+
+	if v, err := ctx.GetValueAsInt8("small"); err != nil || v != 127 {
+		t.Errorf("GetValueAsInt8(small) = %d, %v", v, err)
+	}
+	if _, err := ctx.GetValueAsInt8("big"); err == nil {
+		t.Errorf("expected overflow error for int8")
+	}
+	if v, err := ctx.GetValueAsInt16("big"); err != nil || v != 300 {
+		t.Errorf("GetValueAsInt16(big) = %d, %v", v, err)
+	}
+	if _, err := ctx.GetValueAsUint8("big"); err == nil {
+		t.Errorf("expected overflow error for uint8")
+	}
+	if v, err := ctx.GetValueAsUint16("big"); err != nil || v != 300 {
+		t.Errorf("GetValueAsUint16(big) = %d, %v", v, err)
+	}
+	for _, get := range []func(string) error{
+		func(name string) error { _, err := ctx.GetValueAsUint(name); return err },
+		func(name string) error { _, err := ctx.GetValueAsUint32(name); return err },
+		func(name string) error { _, err := ctx.GetValueAsUint64(name); return err },
+	} {
+		if get("negative") == nil {
+			t.Errorf("expected error for negative unsigned value")
+		}
+		if get("missing") == nil {
+			t.Errorf("expected error for missing flag")
+		}
+	}
+}
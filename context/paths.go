@@ -0,0 +1,70 @@
+package context
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func (ctx *Context) SetWorkDir(dir string) {
+	ctx.workDir = dir
+}
+
+func (ctx *Context) GetWorkDir() (string, error) {
+	if ctx.workDir != "" {
+		return ctx.workDir, nil
+	}
+	return os.Getwd()
+}
+
+func (ctx *Context) ResolvePath(path string) (string, error) {
+	if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, path[1:])
+	}
+
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path), nil
+	}
+
+	workDir, err := ctx.GetWorkDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(workDir, path), nil
+}
+
+func (ctx *Context) GetValueAsPath(name string) (string, error) {
+	if path, ok := ctx.values[name].(string); ok {
+		return path, nil
+	}
+	value, exists := ctx.flags[name]
+	if !exists {
+		return "", errors.New("flag not found")
+	}
+	if value == "" {
+		return "", errors.New("flag has empty value")
+	}
+	return ctx.ResolvePath(value)
+}
+
+func (ctx *Context) GetValueAsPaths(name string) ([]string, error) {
+	pattern, err := ctx.GetValueAsPath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("pattern %s matched no paths", pattern)
+	}
+	return matches, nil
+}
@@ -0,0 +1,42 @@
+package context
+
+import (
+	"path/filepath"
+	"testing"
+
+	prs "github.com/DilemaFixer/Cmd/parser"
+)
+
+func TestResolvePath_ExpandsHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	ctx := NewContext(prs.NewParserInput("copy"))
+	got, err := ctx.ResolvePath("~/backup")
+	if err != nil || got != filepath.Join(home, "backup") {
+		t.Fatalf("expected %s, got %s, err=%v", filepath.Join(home, "backup"), got, err)
+	}
+}
+
+func TestResolvePath_RelativeToWorkDir(t *testing.T) {
+	ctx := NewContext(prs.NewParserInput("copy"))
+	ctx.SetWorkDir("/srv/app")
+
+	if got, _ := ctx.ResolvePath("data/../logs"); got != "/srv/app/logs" {
+		t.Fatalf("expected /srv/app/logs, got %s", got)
+	}
+	if got, _ := ctx.ResolvePath("/etc//hosts"); got != "/etc/hosts" {
+		t.Fatalf("expected /etc/hosts, got %s", got)
+	}
+}
+
+func TestGetValueAsPaths_WithoutMatches_ReturnsError(t *testing.T) {
+	input := prs.NewParserInput("copy")
+	input.InputFlags = append(input.InputFlags, prs.InputFlag{Name: "files", Value: "*.nothing"})
+	ctx := NewContext(input)
+	ctx.SetWorkDir(t.TempDir())
+
+	if _, err := ctx.GetValueAsPaths("files"); err == nil {
+		t.Fatalf("expected error for pattern without matches")
+	}
+}
@@ -0,0 +1,60 @@
+package context
+
+import (
+	"log/slog"
+	"sort"
+	"strings"
+)
+
+const SecretMask = "******"
+
+func (ctx *Context) MarkSecret(name string) {
+	if ctx.secrets == nil {
+		ctx.secrets = make(map[string]bool)
+	}
+	ctx.secrets[name] = true
+}
+
+func (ctx *Context) IsSecret(name string) bool {
+	return ctx.secrets[name]
+}
+
+func (ctx *Context) MaskSecrets(text string) string {
+	values := make([]string, 0)
+	for name := range ctx.secrets {
+		for _, value := range ctx.flagValues[name] {
+			if value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+
+	for _, value := range values {
+		text = strings.ReplaceAll(text, value, SecretMask)
+	}
+	return text
+}
+
+func (ctx *Context) maskedValue(name string) string {
+	if ctx.secrets[name] && ctx.flags[name] != "" {
+		return SecretMask
+	}
+	return ctx.flags[name]
+}
+
+func (ctx Context) LogValue() slog.Value {
+	attrs := []slog.Attr{slog.String("command", ctx.command)}
+	if len(ctx.subcommands) > 0 {
+		attrs = append(attrs, slog.Any("subcommands", ctx.GetSubcommandsAsArr()))
+	}
+
+	flags := make([]any, 0, len(ctx.flags))
+	for _, flag := range ctx.orderedFlags() {
+		flags = append(flags, slog.String(flag, ctx.maskedValue(flag)))
+	}
+	if len(flags) > 0 {
+		attrs = append(attrs, slog.Group("flags", flags...))
+	}
+	return slog.GroupValue(attrs...)
+}
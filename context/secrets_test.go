@@ -0,0 +1,81 @@
+package context
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	prs "github.com/DilemaFixer/Cmd/parser"
+)
+
+func makeSecretContext() *Context {
+	ctx := NewContext(&prs.ParsedInput{
+		Command:     "db",
+		Subcommands: []string{"connect"},
+		InputFlags: []prs.InputFlag{
+			{Name: "user", Value: "admin"},
+			{Name: "password", Value: "hunter2"},
+			{Name: "ssl", Value: ""},
+		},
+	})
+	ctx.MarkSecret("password")
+	return ctx
+}
+
+func TestSecrets_MaskedInFlagArraysAndDumps(t *testing.T) {
+	ctx := makeSecretContext()
+
+	if got := strings.Join(ctx.GetFlagsAsArr(), " "); got != "user=admin password=****** ssl=" {
+		t.Errorf("GetFlagsAsArr = %q", got)
+	}
+	if got := ctx.GetFlagsValuesAsArr(); got[1] != SecretMask {
+		t.Errorf("GetFlagsValuesAsArr = %v", got)
+	}
+	if got := ctx.String(); got != "db connect --user=admin --password='******' --ssl" {
+		t.Errorf("String = %q", got)
+	}
+	if got := ctx.MaskSecrets("login failed for hunter2"); got != "login failed for ******" {
+		t.Errorf("MaskSecrets = %q", got)
+	}
+
+	if value, _ := ctx.GetValueAsString("password"); value != "hunter2" {
+		t.Errorf("expected getters to return the real value, got %q", value)
+	}
+}
+
+func TestSecrets_MaskSecrets_MasksEveryOccurrence(t *testing.T) {
+	ctx := NewContext(&prs.ParsedInput{
+		Command: "login",
+		InputFlags: []prs.InputFlag{
+			{Name: "password", Value: "hunter2"},
+			{Name: "token", Value: "hunter2-token"},
+		},
+	})
+	ctx.MarkSecret("password")
+	ctx.MarkSecret("token")
+
+	cases := map[string]string{
+		"--password=hunter2":      "--password=******",
+		"hunter2_backup xhunter2": "******_backup x******",
+		"hunter2-old":             "******-old",
+		"--token=hunter2-token":   "--token=******",
+	}
+	for text, want := range cases {
+		if got := ctx.MaskSecrets(text); got != want {
+			t.Errorf("MaskSecrets(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestSecrets_MaskedInStructuredLogs(t *testing.T) {
+	ctx := makeSecretContext()
+
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("run", "context", *ctx)
+
+	out := buf.String()
+	if strings.Contains(out, "hunter2") || !strings.Contains(out, "context.flags.password=******") {
+		t.Fatalf("unexpected log output %q", out)
+	}
+}
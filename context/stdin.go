@@ -0,0 +1,17 @@
+package context
+
+import (
+	"io"
+	"os"
+)
+
+func (ctx *Context) SetStdin(stdin io.Reader) {
+	ctx.stdin = stdin
+}
+
+func (ctx *Context) GetStdin() io.Reader {
+	if ctx.stdin == nil {
+		return os.Stdin
+	}
+	return ctx.stdin
+}
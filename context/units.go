@@ -0,0 +1,80 @@
+package context
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DurationFormats  = "300ms, 30s, 5m, 1h30m"
+	TimestampFormats = "2006-01-02T15:04:05Z07:00 (RFC 3339), 2006-01-02 15:04:05, 2006-01-02"
+	ByteSizeFormats  = "1024, 512B, 512K, 10MiB, 2GB (K/M/G/T/P and KiB..PiB are powers of 1024, KB..PB are powers of 1000)"
+)
+
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+var byteSizeUnits = map[string]int64{
+	"":    1,
+	"b":   1,
+	"k":   1 << 10,
+	"kib": 1 << 10,
+	"kb":  1000,
+	"m":   1 << 20,
+	"mib": 1 << 20,
+	"mb":  1000 * 1000,
+	"g":   1 << 30,
+	"gib": 1 << 30,
+	"gb":  1000 * 1000 * 1000,
+	"t":   1 << 40,
+	"tib": 1 << 40,
+	"tb":  1000 * 1000 * 1000 * 1000,
+	"p":   1 << 50,
+	"pib": 1 << 50,
+	"pb":  1000 * 1000 * 1000 * 1000 * 1000,
+}
+
+func ParseTimestamp(value string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %q", value)
+}
+
+func ParseByteSize(value string) (int64, error) {
+	str := strings.TrimSpace(value)
+	split := strings.IndexFunc(str, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if split == -1 {
+		split = len(str)
+	}
+
+	number, unit := str[:split], strings.ToLower(strings.TrimSpace(str[split:]))
+	multiplier, exist := byteSizeUnits[unit]
+	if number == "" || !exist {
+		return 0, fmt.Errorf("invalid byte size %q", value)
+	}
+
+	if !strings.Contains(number, ".") {
+		parsed, err := strconv.ParseInt(number, 10, 64)
+		if err != nil || parsed > math.MaxInt64/multiplier {
+			return 0, fmt.Errorf("invalid byte size %q", value)
+		}
+		return parsed * multiplier, nil
+	}
+
+	parsed, err := strconv.ParseFloat(number, 64)
+	if err != nil || parsed*float64(multiplier) >= math.MaxInt64 {
+		return 0, fmt.Errorf("invalid byte size %q", value)
+	}
+	return int64(parsed * float64(multiplier)), nil
+}
@@ -0,0 +1,62 @@
+package context
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseByteSize_WithSupportedFormats_ReturnsBytes(t *testing.T) {
+	cases := map[string]int64{
+		"1024":   1024,
+		"512B":   512,
+		"512K":   512 * 1024,
+		"512k":   512 * 1024,
+		"10MiB":  10 * 1024 * 1024,
+		"2GB":    2 * 1000 * 1000 * 1000,
+		"1.5G":   1536 * 1024 * 1024,
+		"3 TiB":  3 << 40,
+		"1PB":    1000 * 1000 * 1000 * 1000 * 1000,
+		"0":      0,
+		"  7kb ": 7000,
+	}
+	for input, want := range cases {
+		got, err := ParseByteSize(input)
+		if err != nil {
+			t.Errorf("ParseByteSize(%q) unexpected error: %v", input, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseByteSize(%q) = %d, want %d", input, got, want)
+		}
+	}
+}
+
+func TestParseByteSize_WithInvalidFormats_ReturnsError(t *testing.T) {
+	for _, input := range []string{"", "MiB", "10XB", "-5K", "1.2.3M", "99999999999P"} {
+		if _, err := ParseByteSize(input); err == nil {
+			t.Errorf("ParseByteSize(%q) expected error", input)
+		}
+	}
+}
+
+func TestParseTimestamp_WithSupportedLayouts_ReturnsTime(t *testing.T) {
+	cases := map[string]time.Time{
+		"2024-05-01T10:20:30Z":      time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC),
+		"2024-05-01T10:20:30+02:00": time.Date(2024, 5, 1, 8, 20, 30, 0, time.UTC),
+		"2024-05-01 10:20:30":       time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC),
+		"2024-05-01":                time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for input, want := range cases {
+		got, err := ParseTimestamp(input)
+		if err != nil {
+			t.Errorf("ParseTimestamp(%q) unexpected error: %v", input, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("ParseTimestamp(%q) = %v, want %v", input, got, want)
+		}
+	}
+	if _, err := ParseTimestamp("01/05/2024"); err == nil {
+		t.Errorf("expected error for unsupported layout")
+	}
+}
@@ -23,7 +23,7 @@ func main() {
 
 	parsedInput, err := p.ParseInput(input)
 	if err != nil {
-		fmt.Printf("Parse error: %v\n", err)
+		fmt.Printf("Parse error: %s\n", p.FormatError(err))
 		return
 	}
 
@@ -65,17 +65,17 @@ func main() {
 		// Schema sub-commands
 		NewSub("schema").
 		Endpoint("dump").
-		RequiredString("format"). // Output format (sql, json, yaml)
-		StringOption("output").   // Output file path
-		BoolOption("data").       // Include data in dump
-		BoolOption("compress").   // Compress output
+		RequiredEnum("format", "sql", "json", "yaml"). // Output format
+		StringOption("output").                        // Output file path
+		BoolOption("data").                            // Include data in dump
+		BoolOption("compress").                        // Compress output
 		Handler(schemaDumpHandler).
 		Build().
 		Endpoint("load").
 		Description("Import database schema").
-		RequiredString("file").      // Input file path
-		BoolOption("drop-existing"). // Drop existing tables
-		BoolOption("ignore-errors"). // Continue on errors
+		RequiredFile("file", rtr.PathMustExist, rtr.PathReadable). // Input file path
+		BoolOption("drop-existing").                               // Drop existing tables
+		BoolOption("ignore-errors").                               // Continue on errors
 		Handler(schemaLoadHandler).
 		Build().
 		Build(). // End schema sub-command
@@ -88,7 +88,7 @@ func main() {
 		RequiredInt("port").        // Database port
 		RequiredString("database"). // Database name
 		StringOption("user").       // Username
-		StringOption("password").   // Password
+		SecretOption("password").   // Password (or --password-file, masked in output)
 		BoolOption("ssl").          // Use SSL connection
 		IntOption("timeout").       // Connection timeout
 		Handler(connectionTestHandler).
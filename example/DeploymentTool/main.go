@@ -9,21 +9,22 @@ import (
 )
 
 // Example 3: Deployment Tool with Option Groups
-// This example demonstrates exclusive and inclusive option groups
+// This example demonstrates variant, exclusive and inclusive option groups
 // Usage examples:
-//   myapp deploy --environment=prod --docker --image=myapp:latest --registry=docker.io
-//   myapp deploy --environment=staging --kubernetes --namespace=staging --replicas=3
+//   myapp deploy --environment=prod --platform=docker --image=myapp:latest --registry=docker.io
+//   myapp deploy --environment=staging --platform=kubernetes --namespace=staging --replicas=3
 //   myapp deploy --environment=dev --resources --memory=512 --cpu=2 --monitoring --metrics --alerts=slack
 //   myapp backup --local --path=/backup --compress --encryption=aes256
 //   myapp backup --s3 --bucket=my-backup --region=us-east-1 --access-key=AKIA...
+//   myapp backup --s3 --bucket=my-backup --region=us-east-1 --sse --kms-key=alias/backup
 
 func main() {
 	// Example with exclusive deployment groups
-	input := "deploy --environment=prod --docker --image=myapp:latest --registry=docker.io --resources --memory=1024 --cpu=4"
+	input := "deploy --environment=prod --platform=docker --image=myapp:latest --registry=docker.io --resources --memory=1024 --cpu=4"
 
 	parsedInput, err := p.ParseInput(input)
 	if err != nil {
-		fmt.Printf("Parse error: %v\n", err)
+		fmt.Printf("Parse error: %s\n", p.FormatError(err))
 		return
 	}
 
@@ -31,12 +32,13 @@ func main() {
 	iterator := rtr.NewRoutingIterator(context)
 	router := rtr.NewRouter()
 
-	// Define deployment command with exclusive platform groups
+	// Define deployment command with platform variants
 	router.Endpoint("deploy").
-		RequiredString("environment"). // Required: target environment
+		RequiredString("environment").                                  // Required: target environment
+		RequiredEnum("platform", "docker", "kubernetes", "serverless"). // Required: deployment platform
 
-		// Exclusive deployment platform groups (only one can be used)
-		ExclusiveGroup("docker", "--docker").
+		// Platform variants, selected by the value of --platform
+		Variant("platform", "docker").
 		RequiredString("image").    // Docker image name
 		StringOption("registry").   // Docker registry
 		StringOption("tag").        // Image tag
@@ -44,19 +46,19 @@ func main() {
 		BoolOption("build").        // Build image before deploy
 		BoolOption("push").         // Push to registry
 		EndGroup().
-		ExclusiveGroup("kubernetes", "--kubernetes").
+		Variant("platform", "kubernetes").
 		RequiredString("namespace"). // K8s namespace
 		IntOption("replicas").       // Number of replicas
 		StringOption("config").      // Kubernetes config file
 		StringOption("context").     // Kubectl context
 		BoolOption("wait").          // Wait for deployment to complete
 		EndGroup().
-		ExclusiveGroup("serverless", "--serverless").
-		RequiredString("provider"). // Cloud provider (aws, gcp, azure)
-		StringOption("region").     // Target region
-		StringOption("runtime").    // Runtime environment
-		IntOption("memory").        // Memory allocation in MB
-		IntOption("timeout").       // Timeout in seconds
+		Variant("platform", "serverless").
+		RequiredEnum("provider", "aws", "gcp", "azure"). // Cloud provider
+		StringOption("region").                          // Target region
+		StringOption("runtime").                         // Runtime environment
+		IntOption("memory").                             // Memory allocation in MB
+		IntOption("timeout").                            // Timeout in seconds
 		EndGroup().
 
 		// Inclusive resource configuration group (optional)
@@ -73,7 +75,6 @@ func main() {
 		StringOption("alerts").    // Alert destination (slack, email, etc.)
 		StringOption("dashboard"). // Dashboard URL
 		EndGroup().
-		SetGroupsCanBeIgnored(false). // At least one platform group is required
 		Handler(deployHandler).
 		Register()
 
@@ -99,8 +100,11 @@ func main() {
 		RequiredString("bucket").   // S3 bucket name
 		RequiredString("region").   // AWS region
 		StringOption("access-key"). // AWS access key
-		StringOption("secret-key"). // AWS secret key
+		SecretOption("secret-key"). // AWS secret key (or --secret-key-file)
 		StringOption("prefix").     // Object key prefix
+		SubGroup("sse", "--sse").   // Optional server-side encryption
+		RequiredString("kms-key").  // KMS key used for encryption
+		EndSubGroup().
 		EndGroup().
 		SetGroupsCanBeIgnored(false). // One storage group is required
 		Handler(backupHandler).
@@ -114,12 +118,14 @@ func deployHandler(ctx ctx.Context) error {
 	environment, _ := ctx.GetValueAsString("environment")
 	fmt.Printf("🚀 Deploying to %s environment\n", environment)
 
-	// Check which exclusive platform group is being used
-	if ctx.IsFlagExist("docker") {
+	// Dispatch on the selected platform variant
+	platform, _ := ctx.GetValueAsString("platform")
+	switch platform {
+	case "docker":
 		return handleDockerDeploy(ctx)
-	} else if ctx.IsFlagExist("kubernetes") {
+	case "kubernetes":
 		return handleKubernetesDeploy(ctx)
-	} else if ctx.IsFlagExist("serverless") {
+	case "serverless":
 		return handleServerlessDeploy(ctx)
 	}
 
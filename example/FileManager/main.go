@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	ctx "github.com/DilemaFixer/Cmd/context"
 	p "github.com/DilemaFixer/Cmd/parser"
@@ -23,7 +25,7 @@ func main() {
 
 	parsedInput, err := p.ParseInput(input)
 	if err != nil {
-		fmt.Printf("Parse error: %v\n", err)
+		fmt.Printf("Parse error: %s\n", p.FormatError(err))
 		return
 	}
 
@@ -37,55 +39,57 @@ func main() {
 	// Define file management commands with strict type validation
 	router.NewCmd("file").
 		Endpoint("copy").
-		RequiredString("source").      // Required source path
-		RequiredString("destination"). // Required destination path
-		IntOption("buffer-size").      // Buffer size in bytes (default: 4096)
-		IntOption("threads").          // Number of parallel threads
-		BoolOption("verify").          // Verify copy integrity
-		BoolOption("preserve-attrs").  // Preserve file attributes
-		BoolOption("overwrite").       // Overwrite existing files
-		FloatOption("throttle").       // Throttle speed in MB/s
+		RequiredFile("source").       // Required source file (relative paths are resolved)
+		RequiredPath("destination").  // Required destination path
+		IntOption("buffer-size").     // Buffer size in bytes (default: 4096)
+		IntOption("threads").         // Number of parallel threads
+		BoolOption("verify").         // Verify copy integrity
+		BoolOption("preserve-attrs"). // Preserve file attributes
+		BoolOption("overwrite").      // Overwrite existing files
+		FloatOption("throttle").      // Throttle speed in MB/s
 		Handler(fileCopyHandler).
 		Build().
 		Endpoint("compress").
 		Description("Compress files and directories").
-		RequiredString("input").  // Required input path
-		RequiredString("output"). // Required output file
-		RequiredInt("level").     // Required compression level (1-9)
-		IntOption("threads").     // Number of threads for compression
-		BoolOption("recursive").  // Compress directories recursively
-		StringOption("format").   // Compression format (zip, tar.gz, 7z)
-		FloatOption("max-size").  // Maximum file size to include (MB)
+		RequiredString("input").                     // Required input path
+		RequiredString("output").                    // Required output file
+		RequiredInt("level").                        // Required compression level
+		Range("level", 1, 9).                        // Compression level must be 1-9
+		IntOption("threads").                        // Number of threads for compression
+		Min("threads", 1).                           // At least one thread
+		BoolOption("recursive").                     // Compress directories recursively
+		EnumOption("format", "zip", "tar.gz", "7z"). // Compression format
+		ByteSizeOption("max-size").                  // Maximum file size to include (512K, 10MiB, 2GB)
 		Handler(fileCompressHandler).
 		Build().
 		Endpoint("sync").
 		Description("Synchronize directories").
-		RequiredString("source"). // Required source directory
-		RequiredString("target"). // Required target directory
-		IntOption("batch-size").  // Files to process in each batch
-		IntOption("retry").       // Number of retry attempts
-		FloatOption("timeout").   // Timeout for operations in seconds
-		BoolOption("delete").     // Delete files not in source
-		BoolOption("dry-run").    // Preview changes without applying
-		StringOption("exclude").  // Pattern for files to exclude
+		RequiredString("source").          // Required source directory
+		RequiredString("target").          // Required target directory
+		IntOption("batch-size").           // Files to process in each batch
+		IntOption("retry").                // Number of retry attempts
+		DurationOption("timeout").         // Timeout for operations (30s, 5m)
+		BoolOption("delete").              // Delete files not in source
+		BoolOption("dry-run").             // Preview changes without applying
+		ListOption("exclude", rtr.String). // Patterns for files to exclude (repeatable)
 		Handler(fileSyncHandler).
 		Build().
 		Endpoint("search").
 		Description("Search for files with advanced criteria").
-		RequiredString("pattern").    // Required search pattern
-		RequiredString("directory").  // Required search directory
-		IntOption("max-depth").       // Maximum search depth
-		IntOption("min-size").        // Minimum file size in bytes
-		IntOption("max-size").        // Maximum file size in bytes
-		BoolOption("case-sensitive"). // Case sensitive search
-		BoolOption("include-hidden"). // Include hidden files
-		StringOption("type").         // File type filter (file, dir, link)
+		RequiredString("pattern").                 // Required search pattern
+		RequiredString("directory").               // Required search directory
+		IntOption("max-depth").                    // Maximum search depth
+		IntOption("min-size").                     // Minimum file size in bytes
+		IntOption("max-size").                     // Maximum file size in bytes
+		BoolOption("case-sensitive").              // Case sensitive search
+		BoolOption("include-hidden").              // Include hidden files
+		EnumOption("type", "file", "dir", "link"). // File type filter
 		Handler(fileSearchHandler).
 		Build().
 		Endpoint("monitor").
 		Description("Monitor directory for changes").
 		RequiredString("directory").   // Required directory to monitor
-		IntOption("interval").         // Check interval in seconds
+		DurationOption("interval").    // Check interval (5s, 1m)
 		FloatOption("size-threshold"). // Size change threshold in MB
 		BoolOption("recursive").       // Monitor subdirectories
 		StringOption("output").        // Output log file
@@ -260,12 +264,12 @@ func fileCompressHandler(ctx ctx.Context) error {
 	format := ctx.GetValueOrDefault("format", "tar.gz")
 	recursive := ctx.GetValueAsBool("recursive")
 
-	var maxSize float64
+	var maxSize int64
 	if ctx.IsFlagExist("max-size") {
-		if size, err := ctx.GetValueAsFloat64("max-size"); err != nil {
+		if size, err := ctx.GetValueAsByteSize("max-size"); err != nil {
 			return fmt.Errorf("invalid max-size: %v", err)
 		} else if size <= 0 {
-			return fmt.Errorf("max-size must be positive, got: %.2f", size)
+			return fmt.Errorf("max-size must be positive, got: %d", size)
 		} else {
 			maxSize = size
 		}
@@ -276,7 +280,7 @@ func fileCompressHandler(ctx ctx.Context) error {
 	fmt.Printf("♻️  Recursive: %v\n", recursive)
 
 	if maxSize > 0 {
-		fmt.Printf("📏 Max Size: %.2f MB\n", maxSize)
+		fmt.Printf("📏 Max Size: %d bytes\n", maxSize)
 	}
 
 	fmt.Println("\n🔄 Compressing files...")
@@ -329,12 +333,12 @@ func fileSyncHandler(ctx ctx.Context) error {
 		}
 	}
 
-	var timeout float64 = 30.0 // default 30 seconds
+	timeout := 30 * time.Second // default
 	if ctx.IsFlagExist("timeout") {
-		if t, err := ctx.GetValueAsFloat64("timeout"); err != nil {
+		if t, err := ctx.GetValueAsDuration("timeout"); err != nil {
 			return fmt.Errorf("invalid timeout: %v", err)
 		} else if t <= 0 {
-			return fmt.Errorf("timeout must be positive, got: %.2f", t)
+			return fmt.Errorf("timeout must be positive, got: %s", t)
 		} else {
 			timeout = t
 		}
@@ -342,15 +346,15 @@ func fileSyncHandler(ctx ctx.Context) error {
 
 	delete := ctx.GetValueAsBool("delete")
 	dryRun := ctx.GetValueAsBool("dry-run")
-	exclude := ctx.GetValueOrDefault("exclude", "")
+	exclude, _ := ctx.GetValueAsStringSlice("exclude")
 
 	fmt.Printf("📦 Batch Size: %d\n", batchSize)
 	fmt.Printf("🔄 Retries: %d\n", retries)
-	fmt.Printf("⏰ Timeout: %.1f seconds\n", timeout)
+	fmt.Printf("⏰ Timeout: %s\n", timeout)
 	fmt.Printf("🗑️  Delete Extra: %v\n", delete)
 
-	if exclude != "" {
-		fmt.Printf("🚫 Exclude Pattern: %s\n", exclude)
+	if len(exclude) > 0 {
+		fmt.Printf("🚫 Exclude Patterns: %s\n", strings.Join(exclude, ", "))
 	}
 
 	if dryRun {
@@ -488,12 +492,12 @@ func fileMonitorHandler(ctx ctx.Context) error {
 	fmt.Printf("📁 Monitoring: %s\n", directory)
 
 	// Handle optional parameters
-	interval := 5 // default 5 seconds
+	interval := 5 * time.Second // default
 	if ctx.IsFlagExist("interval") {
-		if i, err := ctx.GetValueAsInt("interval"); err != nil {
+		if i, err := ctx.GetValueAsDuration("interval"); err != nil {
 			return fmt.Errorf("invalid interval: %v", err)
-		} else if i < 1 || i > 3600 {
-			return fmt.Errorf("interval must be between 1-3600 seconds, got: %d", i)
+		} else if i < time.Second || i > time.Hour {
+			return fmt.Errorf("interval must be between 1s and 1h, got: %s", i)
 		} else {
 			interval = i
 		}
@@ -513,7 +517,7 @@ func fileMonitorHandler(ctx ctx.Context) error {
 	recursive := ctx.GetValueAsBool("recursive")
 	outputFile := ctx.GetValueOrDefault("output", "monitor.log")
 
-	fmt.Printf("⏰ Check Interval: %d seconds\n", interval)
+	fmt.Printf("⏰ Check Interval: %s\n", interval)
 	fmt.Printf("📊 Size Threshold: %.2f MB\n", sizeThreshold)
 	fmt.Printf("♻️  Recursive: %v\n", recursive)
 	fmt.Printf("📄 Output Log: %s\n", outputFile)
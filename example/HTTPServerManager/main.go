@@ -11,18 +11,19 @@ import (
 // Example 1: HTTPServerManager
 // This example demonstrates basic command definition with different option types
 // Usage examples:
-//   myapp server start --host=localhost --port=8080 --debug
+//   myapp server start --listen=localhost:8080 --debug
+//   myapp server start --listen=:8080 --upstream=https://api.internal --allow=10.0.0.0/8
 //   myapp server stop --force
 //   myapp server status
 
 func main() {
 	// Simulate command line input - in real app you would use os.Args
-	input := "server start --host=localhost --port=8080 --debug"
+	input := "server start --listen=localhost:8080 --debug"
 
 	// Parse the input
 	parsedInput, err := p.ParseInput(input)
 	if err != nil {
-		fmt.Printf("Parse error: %v\n", err)
+		fmt.Printf("Parse error: %s\n", p.FormatError(err))
 		return
 	}
 
@@ -36,11 +37,12 @@ func main() {
 		// Start server endpoint
 		Endpoint("start").
 		Description("Start the HTTP server").
-		StringOption("host").   // Optional host (default will be used if not provided)
-		IntOption("port").      // Optional port
-		BoolOption("debug").    // Debug mode flag
-		BoolOption("ssl").      // Enable SSL flag
-		StringOption("config"). // Optional config file path
+		HostPortOption("listen").               // Optional listen address (default will be used if not provided)
+		URLOption("upstream", "http", "https"). // Optional upstream to proxy requests to
+		CIDROption("allow").                    // Optional client network allowed to connect
+		BoolOption("debug").                    // Debug mode flag
+		BoolOption("ssl").                      // Enable SSL flag
+		StringOption("config").                 // Optional config file path
 		Handler(startServerHandler).
 		Build().
 
@@ -69,8 +71,13 @@ func startServerHandler(ctx ctx.Context) error {
 	fmt.Println("🚀 Starting HTTP Server...")
 
 	// Get configuration values with defaults
-	host := ctx.GetValueOrDefault("host", "localhost")
-	port := ctx.GetValueOrDefault("port", "3000")
+	host, port := "localhost", uint16(3000)
+	if ctx.IsFlagExist("listen") {
+		var err error
+		if host, port, err = ctx.GetValueAsHostPort("listen"); err != nil {
+			return fmt.Errorf("invalid listen address: %v", err)
+		}
+	}
 
 	// Check boolean flags
 	debug := ctx.GetValueAsBool("debug")
@@ -80,7 +87,13 @@ func startServerHandler(ctx ctx.Context) error {
 	configFile := ctx.GetValueOrDefault("config", "server.conf")
 
 	fmt.Printf("📍 Host: %s\n", host)
-	fmt.Printf("🔌 Port: %s\n", port)
+	fmt.Printf("🔌 Port: %d\n", port)
+	if upstream, err := ctx.GetValueAsURL("upstream"); err == nil {
+		fmt.Printf("↪️  Upstream: %s\n", upstream.Redacted())
+	}
+	if allow, err := ctx.GetValueAsCIDR("allow"); err == nil {
+		fmt.Printf("🛡️  Allowed network: %s\n", allow)
+	}
 	fmt.Printf("🐛 Debug Mode: %v\n", debug)
 	fmt.Printf("🔒 SSL Enabled: %v\n", ssl)
 	fmt.Printf("⚙️  Config File: %s\n", configFile)
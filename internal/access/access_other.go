@@ -0,0 +1,21 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package access
+
+import (
+	"errors"
+	"os"
+)
+
+var errReadOnly = errors.New("permission denied")
+
+func Writable(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.Mode().Perm()&0o222 == 0 {
+		return errReadOnly
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package access
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWritable_LeavesDirectoryUntouched(t *testing.T) {
+	dir := t.TempDir()
+	if err := Writable(dir); err != nil {
+		t.Fatalf("expected writable dir, got %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Fatalf("write check left files behind: %v", entries)
+	}
+	if err := Writable(filepath.Join(dir, "missing")); err == nil {
+		t.Fatalf("expected error for missing path")
+	}
+}
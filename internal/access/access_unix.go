@@ -0,0 +1,11 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package access
+
+import "syscall"
+
+const writeOK = 0x2
+
+func Writable(path string) error {
+	return syscall.Access(path, writeOK)
+}
@@ -0,0 +1,29 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package term
+
+import "errors"
+
+var errUnsupported = errors.New("terminal control is not supported on this platform")
+
+func IsTerminal(fd uintptr) bool {
+	return false
+}
+
+func ReadPassword(fd uintptr) ([]byte, error) {
+	return nil, errUnsupported
+}
+
+func Size(fd uintptr) (int, int, error) {
+	return 0, 0, errUnsupported
+}
+
+type State struct{}
+
+func MakeRaw(fd uintptr) (*State, error) {
+	return nil, errUnsupported
+}
+
+func Restore(fd uintptr, state *State) error {
+	return errUnsupported
+}
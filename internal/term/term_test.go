@@ -0,0 +1,42 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package term
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestIsTerminal_WithRegularFile_ReturnsFalse(t *testing.T) {
+	file, err := os.CreateTemp(t.TempDir(), "term")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	if IsTerminal(file.Fd()) {
+		t.Fatalf("expected regular file not to be a terminal")
+	}
+	if _, err := ReadPassword(file.Fd()); err == nil {
+		t.Fatalf("expected error reading password from regular file")
+	}
+	if _, err := MakeRaw(file.Fd()); err == nil {
+		t.Fatalf("expected error switching regular file to raw mode")
+	}
+	if _, _, err := Size(file.Fd()); err == nil {
+		t.Fatalf("expected error reading size of regular file")
+	}
+}
+
+func TestReadLine_StopsAtNewlineAndTrimsCarriageReturn(t *testing.T) {
+	reader := strings.NewReader("s3cret\r\nnext")
+	line, err := readLine(reader)
+	if err != nil || string(line) != "s3cret" {
+		t.Fatalf("readLine = %q, %v", line, err)
+	}
+	line, err = readLine(reader)
+	if err != nil || string(line) != "next" {
+		t.Fatalf("readLine = %q, %v", line, err)
+	}
+}
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"strings"
+	"unicode"
 )
 
 type InputFlag struct {
@@ -16,3 +17,10 @@ func (f InputFlag) HaveInputValue() bool {
 func isFlag(str string) bool {
 	return strings.HasPrefix(str, "--")
 }
+
+func isShortFlag(str string) bool {
+	if len(str) < 2 || str[0] != '-' || str[1] == '-' {
+		return false
+	}
+	return unicode.IsLetter([]rune(str[1:])[0])
+}
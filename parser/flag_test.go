@@ -55,3 +55,12 @@ func TestIfFlag_WordWithFlagPrefix_ReturnTrue(t *testing.T) {
 		t.Fatalf("expected true on word with flag prefix")
 	}
 }
+
+func TestIsShortFlag_WithSingleDashLetter_ReturnTrue(t *testing.T) {
+	if !isShortFlag("-v") || !isShortFlag("-vvv") {
+		t.Fatalf("expected true on single dash followed by letter")
+	}
+	if isShortFlag("-") || isShortFlag("-1") || isShortFlag("--verbose") {
+		t.Fatalf("expected false on dash, negative number and long flag")
+	}
+}
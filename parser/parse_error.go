@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+type ParseError struct {
+	Msg    string
+	Input  string
+	Token  int
+	Offset int
+	End    int
+	Line   int
+	Column int
+}
+
+func (err *ParseError) Error() string {
+	return err.Msg
+}
+
+func (err *ParseError) Caret() string {
+	lineStart := strings.LastIndexByte(err.Input[:err.Offset], '\n') + 1
+	lineEnd := len(err.Input)
+	if i := strings.IndexByte(err.Input[err.Offset:], '\n'); i >= 0 {
+		lineEnd = err.Offset + i
+	}
+
+	var marker strings.Builder
+	for _, r := range err.Input[lineStart:err.Offset] {
+		if r == '\t' {
+			marker.WriteRune('\t')
+		} else {
+			marker.WriteRune(' ')
+		}
+	}
+	marker.WriteRune('^')
+	end := min(max(err.End, err.Offset), lineEnd)
+	for range utf8.RuneCountInString(err.Input[err.Offset:end]) - 1 {
+		marker.WriteRune('~')
+	}
+	return err.Input[lineStart:lineEnd] + "\n" + marker.String()
+}
+
+func FormatError(err error) string {
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		return err.Error()
+	}
+	return err.Error() + "\n" + parseErr.Caret()
+}
+
+func newParseError(msg string, input string, tokenIndex int, start int, end int) *ParseError {
+	line, column := position(input, start)
+	return &ParseError{
+		Msg:    msg,
+		Input:  input,
+		Token:  tokenIndex,
+		Offset: start,
+		End:    end,
+		Line:   line,
+		Column: column,
+	}
+}
+
+func columnError(msg string, input string, tokenIndex int, start int, end int) *ParseError {
+	err := newParseError(msg, input, tokenIndex, start, end)
+	err.Msg = fmt.Sprintf("Parsing err: %s at column %d", msg, err.Column)
+	return err
+}
+
+func tokenError(msg string, input string, tokens []token, index int) error {
+	tok := tokens[index]
+	return newParseError(msg, input, index, tok.start, tok.end)
+}
+
+func position(str string, offset int) (int, int) {
+	line := strings.Count(str[:offset], "\n") + 1
+	lineStart := strings.LastIndexByte(str[:offset], '\n') + 1
+	return line, utf8.RuneCountInString(str[lineStart:offset]) + 1
+}
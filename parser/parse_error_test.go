@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseInput_WithSubcommandAfterFlag_ReturnsPositionedError(t *testing.T) {
+	input := "deploy --env=prod  service"
+	_, err := ParseInput(input)
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected ParseError, got %T: %v", err, err)
+	}
+	if parseErr.Token != 2 || parseErr.Offset != 19 || parseErr.End != 26 || parseErr.Line != 1 || parseErr.Column != 20 {
+		t.Fatalf("unexpected position: %+v", parseErr)
+	}
+
+	want := "deploy --env=prod  service\n                   ^~~~~~~"
+	if got := parseErr.Caret(); got != want {
+		t.Fatalf("expected caret\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestParseInput_WithUnterminatedQuote_PointsAtQuote(t *testing.T) {
+	_, err := ParseInput("run --name=\"ок")
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected ParseError, got %T: %v", err, err)
+	}
+	if parseErr.Offset != 11 || parseErr.Column != 12 {
+		t.Fatalf("unexpected position: %+v", parseErr)
+	}
+	if got, want := parseErr.Caret(), "run --name=\"ок\n           ^~~"; got != want {
+		t.Fatalf("expected caret\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestParseError_Caret_UsesOffendingLineOnly(t *testing.T) {
+	_, err := ParseInput("run --a\n\tsub")
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected ParseError, got %T: %v", err, err)
+	}
+	if parseErr.Line != 2 || parseErr.Column != 2 {
+		t.Fatalf("unexpected position: %+v", parseErr)
+	}
+	if got, want := parseErr.Caret(), "\tsub\n\t^~~"; got != want {
+		t.Fatalf("expected caret %q, got %q", want, got)
+	}
+}
+
+func TestFormatError_WithPlainError_ReturnsMessage(t *testing.T) {
+	_, err := ParseInput("   ")
+	if got := FormatError(err); got != "Parsing err: empty or only whitespace in string" {
+		t.Fatalf("unexpected message %q", got)
+	}
+}
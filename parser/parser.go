@@ -4,42 +4,51 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"unicode"
 )
 
 func ParseInput(input string) (*ParsedInput, error) {
-	input = strings.TrimSpace(input)
+	return parseInput(input, nil)
+}
+
+func parseInput(input string, vars *expander) (*ParsedInput, error) {
 	if !validateInput(input) {
 		return nil, fmt.Errorf("Parsing err: empty or only whitespace in string")
 	}
 
-	parts, partsCount := cutInput(input)
-	if partsCount == 0 {
+	tokens, err := tokenizeWith(input, vars, false, false, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
 		return nil, fmt.Errorf("Parsing err: empty or only whitespace in string")
 	}
+	return parseTokens(input, tokens, 0, len(tokens))
+}
 
-	if isFlag(parts[0]) {
-		return nil, fmt.Errorf("First word must be command , not flag %s", parts[0])
+func parseTokens(input string, tokens []token, from int, to int) (*ParsedInput, error) {
+	first := tokens[from].value
+	if isFlag(first) || isShortFlag(first) {
+		return nil, tokenError(fmt.Sprintf("First word must be command , not flag %s", first), input, tokens, from)
 	}
 
-	result := NewParserInput(parts[0])
-	parts = removeFirst(parts, 1)
-
-	if partsCount == 0 {
-		return result, nil
-	}
+	result := NewParserInput(first)
 
 	var flagsQueueStarted bool = false
-	for _, str := range parts {
+	for i := from + 1; i < to; i++ {
+		str := tokens[i].value
 		if isFlag(str) {
 			flagsQueueStarted = true
 			flag, err := parseFlag(str)
 			if err != nil {
-				return nil, err
+				return nil, tokenError(err.Error(), input, tokens, i)
 			}
 			result.InputFlags = append(result.InputFlags, flag)
+		} else if isShortFlag(str) {
+			result.Subcommands = append(result.Subcommands, str)
 		} else {
 			if flagsQueueStarted {
-				return nil, fmt.Errorf("Subcommand command %s can't go after flag", str)
+				return nil, tokenError(fmt.Sprintf("Subcommand command %s can't go after flag", str), input, tokens, i)
 			}
 			result.Subcommands = append(result.Subcommands, str)
 		}
@@ -48,9 +57,35 @@ func ParseInput(input string) (*ParsedInput, error) {
 	return result, nil
 }
 
+func ParseShortFlags(str string) ([]InputFlag, error) {
+	if !isShortFlag(str) {
+		return nil, fmt.Errorf("Parsing err: %s is not a short flag", str)
+	}
+
+	names, value, hasValue := strings.Cut(str[1:], "=")
+	if hasValue {
+		if len([]rune(names)) != 1 {
+			return nil, fmt.Errorf("Parsing err: grouped short flags -%s can't share value %s", names, value)
+		}
+		flag, err := parseFlag("--" + str[1:])
+		if err != nil {
+			return nil, err
+		}
+		return []InputFlag{flag}, nil
+	}
+
+	flags := make([]InputFlag, 0, len(names))
+	for _, name := range names {
+		if !unicode.IsLetter(name) {
+			return nil, fmt.Errorf("Parsing err: invalid short flag %s, short flags must be letters", str)
+		}
+		flags = append(flags, InputFlag{Name: string(name)})
+	}
+	return flags, nil
+}
+
 func parseFlag(str string) (InputFlag, error) {
-	str = strings.TrimSpace(str)
-	if str == "" {
+	if strings.TrimSpace(str) == "" {
 		return InputFlag{}, fmt.Errorf("empty input string")
 	}
 
@@ -60,17 +95,12 @@ func parseFlag(str string) (InputFlag, error) {
 	if strings.Contains(str, "=") {
 		strParts := strings.SplitN(str, "=", 2)
 		name := strings.TrimSpace(strParts[0])
-		value := strings.TrimSpace(strParts[1])
+		value := strParts[1]
 
 		if value == "" {
 			return flag, fmt.Errorf("Empty setter for %s", name)
 		}
 
-		if (strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"")) ||
-			(strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'")) {
-			value = value[1 : len(value)-1]
-		}
-
 		flag = InputFlag{
 			Name:  name,
 			Value: value,
@@ -85,21 +115,41 @@ func parseFlag(str string) (InputFlag, error) {
 	return flag, nil
 }
 
+func unquoteArgs(args []string) []string {
+	unquoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		arg = strings.TrimSpace(arg)
+		if name, value, found := strings.Cut(arg, "="); found && (isFlag(name) || isShortFlag(name)) {
+			arg = name + "=" + unquoteArgValue(value)
+		}
+		unquoted = append(unquoted, arg)
+	}
+	return unquoted
+}
+
+func unquoteArgValue(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
 func ParseOSArgs() (*ParsedInput, error) {
 	if len(os.Args) < 2 {
 		return nil, fmt.Errorf("Parsing err: empty args")
 	}
-	return ParseArgs(os.Args[1:])
+	return ParseArgs(unquoteArgs(os.Args[1:]))
 }
 
 func ParseArgs(args []string) (*ParsedInput, error) {
-	parts := trimNonEmpty(args)
+	parts := nonEmpty(args)
 	if len(parts) == 0 {
 		return nil, fmt.Errorf("Parsing err: empty args")
 	}
 
 	first := parts[0]
-	if isFlag(first) {
+	if isFlag(first) || isShortFlag(first) {
 		return nil, fmt.Errorf("First word must be command , not flag %s", first)
 	}
 	if first == "--" {
@@ -115,10 +165,6 @@ func ParseArgs(args []string) (*ParsedInput, error) {
 
 	flagsStarted := false
 	for _, tok := range parts {
-		tok = strings.TrimSpace(tok)
-		if tok == "" {
-			continue
-		}
 		if tok == "--" {
 			return nil, fmt.Errorf("Invalid flag: --")
 		}
@@ -132,6 +178,10 @@ func ParseArgs(args []string) (*ParsedInput, error) {
 			res.InputFlags = append(res.InputFlags, flag)
 			continue
 		}
+		if isShortFlag(tok) {
+			res.Subcommands = append(res.Subcommands, tok)
+			continue
+		}
 
 		// не флаг → это сабкоманда
 		if flagsStarted {
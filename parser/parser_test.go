@@ -142,3 +142,86 @@ func TestParseFlag_WithoutPrefix_ReturnsFlag(t *testing.T) {
 		t.Fatalf("expected %v, got %v", want, got)
 	}
 }
+
+func TestParseInput_WithShortFlags_KeepsWordsInPlace(t *testing.T) {
+	parsedInput, err := ParseInput("command -vvx pattern -a1 -ab=1 --long -o=out.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"-vvx", "pattern", "-a1", "-ab=1", "-o=out.txt"}; !reflect.DeepEqual(parsedInput.Subcommands, want) {
+		t.Fatalf("expected subcommands %v, got %v", want, parsedInput.Subcommands)
+	}
+	if want := []InputFlag{{Name: "long"}}; !reflect.DeepEqual(parsedInput.InputFlags, want) {
+		t.Fatalf("expected %v, got %v", want, parsedInput.InputFlags)
+	}
+}
+
+func TestParseArgs_WithNegativeNumber_ReturnsSubcommand(t *testing.T) {
+	parsedInput, err := ParseArgs([]string{"command", "-5", "-v"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(parsedInput.Subcommands, []string{"-5", "-v"}) {
+		t.Fatalf("expected subcommands -5 -v, got %v", parsedInput.Subcommands)
+	}
+}
+
+func TestParseShortFlags_ReturnsFlagPerLetter(t *testing.T) {
+	flags, err := ParseShortFlags("-vvx")
+	want := []InputFlag{{Name: "v"}, {Name: "v"}, {Name: "x"}}
+	if err != nil || !reflect.DeepEqual(flags, want) {
+		t.Fatalf("expected %v, got %v (%v)", want, flags, err)
+	}
+	flags, err = ParseShortFlags("-o=out.txt")
+	if err != nil || !reflect.DeepEqual(flags, []InputFlag{{Name: "o", Value: "out.txt"}}) {
+		t.Fatalf("unexpected flags %v (%v)", flags, err)
+	}
+}
+
+func TestParseShortFlags_WithGroupedShortFlagsAndValue_ReturnsError(t *testing.T) {
+	if _, err := ParseShortFlags("-ab=1"); err == nil || err.Error() != "Parsing err: grouped short flags -ab can't share value 1" {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err := ParseShortFlags("-a1"); err == nil || err.Error() != "Parsing err: invalid short flag -a1, short flags must be letters" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestParseArgsWithOptions_WithQuotedValues_TrimsAndStripsQuotes(t *testing.T) {
+	parsedInput, err := ParseArgsWithOptions([]string{"command", "--name= 'a b' ", `--path="/tmp/x"`, "--raw=it's"}, ParseOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []InputFlag{{Name: "name", Value: "a b"}, {Name: "path", Value: "/tmp/x"}, {Name: "raw", Value: "it's"}}
+	if !reflect.DeepEqual(parsedInput.InputFlags, want) {
+		t.Fatalf("expected %v, got %v", want, parsedInput.InputFlags)
+	}
+}
+
+func TestParseInput_WithQuotedValues_KeepsTokenizedValue(t *testing.T) {
+	parsedInput, err := ParseInput(`command --name="'a b'" --pad=' x '`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []InputFlag{{Name: "name", Value: "'a b'"}, {Name: "pad", Value: " x "}}
+	if !reflect.DeepEqual(parsedInput.InputFlags, want) {
+		t.Fatalf("expected %v, got %v", want, parsedInput.InputFlags)
+	}
+}
+
+func TestParseArgs_WithArgsOutput_RoundTrips(t *testing.T) {
+	input := &ParsedInput{
+		Command:     "echo",
+		Subcommands: []string{" padded ", `"quoted"`},
+		InputFlags:  []InputFlag{{Name: "name", Value: "'a b'"}, {Name: "pad", Value: " x "}},
+	}
+
+	parsed, err := ParseArgs(input.Args())
+	if err != nil || !reflect.DeepEqual(parsed, input) {
+		t.Fatalf("expected %+v, got %+v (%v)", input, parsed, err)
+	}
+}
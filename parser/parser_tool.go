@@ -1,7 +1,10 @@
 package parser
 
 import (
+	"fmt"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 func validateInput(input string) bool {
@@ -9,55 +12,134 @@ func validateInput(input string) bool {
 	return input != ""
 }
 
-func cutInput(str string) ([]string, int) {
-	if strings.TrimSpace(str) == "" {
-		return nil, 0
-	}
+type token struct {
+	value    string
+	start    int
+	end      int
+	operator bool
+}
+
+func tokenize(str string) ([]token, error) {
+	return tokenizeWith(str, nil, false, false, false)
+}
 
-	var parts []string
+func tokenizeWith(str string, vars *expander, operators bool, lenient bool, comments bool) ([]token, error) {
+	var tokens []token
 	var buf strings.Builder
-	inSingleQuotes := false
-	inDoubleQuotes := false
+	inToken := false
+	literal := false
+	start := 0
+	quote := rune(0)
+	quoteStart := 0
+	escaped := false
 
-	for _, r := range str {
-		switch r {
-		case ' ':
-			if inSingleQuotes || inDoubleQuotes {
-				buf.WriteRune(r)
+	flush := func(end int) {
+		if inToken && (literal || buf.Len() > 0) {
+			tokens = append(tokens, token{value: buf.String(), start: start, end: end})
+		}
+		buf.Reset()
+		inToken = false
+		literal = false
+	}
+
+	for i := 0; i < len(str); {
+		pos := i
+		r, size := utf8.DecodeRuneInString(str[i:])
+		i += size
+
+		if comments && r == '#' && !inToken && quote == 0 && !escaped {
+			if end := strings.IndexByte(str[i:], '\n'); end >= 0 {
+				i += end
 			} else {
-				if buf.Len() > 0 {
-					parts = append(parts, buf.String())
-					buf.Reset()
-				}
+				i = len(str)
+			}
+			continue
+		}
+		if !inToken && !unicode.IsSpace(r) {
+			inToken = true
+			start = pos
+		}
+
+		switch {
+		case escaped:
+			escaped = false
+			if r == '\n' {
+				continue
+			}
+			if quote == '"' && !strings.ContainsRune("\"\\$`", r) {
+				buf.WriteRune('\\')
 			}
-		case '"':
-			inDoubleQuotes = !inDoubleQuotes
-		case '\'':
-			inSingleQuotes = !inSingleQuotes
+			buf.WriteRune(r)
+			literal = true
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+				continue
+			}
+			buf.WriteRune(r)
+		case r == '\\':
+			escaped = true
+		case r == '$' && vars != nil && isVariableStart(str[i:]):
+			value, end, err := vars.expand(str, pos, len(tokens))
+			if err != nil {
+				return nil, err
+			}
+			buf.WriteString(value)
+			i = end
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+				continue
+			}
+			buf.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			quoteStart = pos
+			literal = true
+		case unicode.IsSpace(r):
+			flush(pos)
+		case operators && strings.ContainsRune(";&|", r):
+			op := chainOperatorAt(str, pos)
+			if op == "" && lenient {
+				buf.WriteRune(r)
+				literal = true
+				continue
+			}
+			flush(pos)
+			if op == "" {
+				return nil, columnError(fmt.Sprintf("unsupported operator %c", r), str, len(tokens), pos, pos+1)
+			}
+			tokens = append(tokens, token{value: op, start: pos, end: pos + len(op), operator: true})
+			i = pos + len(op)
 		default:
 			buf.WriteRune(r)
+			literal = true
 		}
 	}
 
-	if buf.Len() > 0 {
-		parts = append(parts, buf.String())
+	if lenient {
+		flush(len(str))
+		return tokens, nil
 	}
-
-	return parts, len(parts)
-}
-
-func removeFirst[T any](parts []T, count int) []T {
-	if partsLen := len(parts); partsLen == 0 || partsLen < count {
-		return parts
+	if escaped {
+		return nil, columnError("unfinished escape", str, len(tokens), len(str)-1, len(str))
+	}
+	if quote != 0 {
+		kind := "double"
+		if quote == '\'' {
+			kind = "single"
+		}
+		return nil, columnError(fmt.Sprintf("unterminated %s quote", kind), str, len(tokens), quoteStart, len(str))
 	}
-	return parts[count:]
+	flush(len(str))
+	return tokens, nil
 }
 
-func trimNonEmpty(ss []string) []string {
+func nonEmpty(ss []string) []string {
 	out := make([]string, 0, len(ss))
 	for _, s := range ss {
-		if t := strings.TrimSpace(s); t != "" {
-			out = append(out, t)
+		if strings.TrimSpace(s) != "" {
+			out = append(out, s)
 		}
 	}
 	return out
@@ -25,124 +25,121 @@ func TestValidateInput_WithValidString_ReturnTrue(t *testing.T) {
 	}
 }
 
-// --- CutInput ---
+// --- Tokenize ---
 
-func TestCutInput_WithEmptyString_ReturnEmptyStringAndZero(t *testing.T) {
-	if value, leng := cutInput(""); len(value) != 0 && leng != 0 {
-		t.Fatalf("expected nil arr and zero leng for empty string, but have arr len:%d | returned len:%d", len(value), leng)
+func tokenValues(tokens []token) []string {
+	values := make([]string, 0, len(tokens))
+	for _, tok := range tokens {
+		values = append(values, tok.value)
 	}
+	return values
 }
 
-func TestCutInput_WithSpaceOnlyString_ReturnEmptyStringAndZero(t *testing.T) {
-	if value, leng := cutInput("    "); len(value) != 0 && leng != 0 {
-		t.Fatalf("expected nil arr and zero leng for space only string, but have arr len:%d | returned len:%d", len(value), leng)
+func TestTokenize_WithEmptyString_ReturnEmptySlice(t *testing.T) {
+	if tokens, err := tokenize(""); len(tokens) != 0 || err != nil {
+		t.Fatalf("expected empty arr for empty string, but have arr len:%d | err:%v", len(tokens), err)
 	}
 }
 
-func TestCutInput_WithValidString_ReturnArrWithOneItem(t *testing.T) {
-	var value []string
-	var leng int
+func TestTokenize_WithSpaceOnlyString_ReturnEmptySlice(t *testing.T) {
+	if tokens, err := tokenize("    "); len(tokens) != 0 || err != nil {
+		t.Fatalf("expected empty arr for space only string, but have arr len:%d | err:%v", len(tokens), err)
+	}
+}
 
-	if value, leng = cutInput("value"); len(value) != 1 && leng != 1 {
-		t.Fatalf("expected arr with one item and 1 leng for valid string, but have arr len:%d | returned len:%d", len(value), leng)
+func TestTokenize_WithValidString_ReturnArrWithOneItem(t *testing.T) {
+	tokens, err := tokenize("value")
+	if err != nil || len(tokens) != 1 {
+		t.Fatalf("expected arr with one item for valid string, but have arr len:%d | err:%v", len(tokens), err)
 	}
 
-	if value[0] != "value" {
-		t.Fatalf("expected arr with item 'value' , but have %s", value[0])
+	if tokens[0].value != "value" {
+		t.Fatalf("expected arr with item 'value' , but have %s", tokens[0].value)
 	}
 }
 
-func TestCutInput_WithValidString_ReturnArrWithTwoItems(t *testing.T) {
+func TestTokenize_WithValidString_ReturnArrWithTwoItems(t *testing.T) {
 	expected := []string{
 		"value1",
 		"value2",
 	}
 
-	var values []string
-	var leng int
-
-	if values, leng = cutInput("value1 value2"); len(values) != 2 && leng != 2 {
-		t.Fatalf("expected arr with one item and 2 leng for valid string, but have arr len:%d | returned len:%d", len(values), leng)
+	tokens, err := tokenize("value1 value2")
+	if err != nil || len(tokens) != 2 {
+		t.Fatalf("expected arr with two items for valid string, but have arr len:%d | err:%v", len(tokens), err)
 	}
 
 	for i, value := range expected {
-		if values[i] != value {
-			t.Fatalf("expected item %s at position %d , but have %s", value, i, values[i])
+		if tokens[i].value != value {
+			t.Fatalf("expected item %s at position %d , but have %s", value, i, tokens[i].value)
 		}
 	}
 }
 
-func TestCutInput_WithаDoubleQuotes_ReturnArrWithOneItem(t *testing.T) {
-	expected := []string{
-		"value1 value2",
-	}
-	var values []string
-	var leng int
-
-	if values, leng = cutInput(`"value1 value2"`); len(values) != 1 && leng != 1 {
-		t.Fatalf("expected arr with one item and 1 leng for valid string, but have arr len:%d | returned len:%d", len(values), leng)
-	}
-
-	for i, value := range expected {
-		if values[i] != value {
-			t.Fatalf("expected item %s at position %d , but have %s", value, i, values[i])
-		}
+func TestTokenize_WithDoubleQuotes_ReturnArrWithOneItem(t *testing.T) {
+	tokens, err := tokenize(`"value1 value2"`)
+	if values := tokenValues(tokens); err != nil || !reflect.DeepEqual(values, []string{"value1 value2"}) {
+		t.Fatalf("expected arr with item 'value1 value2', but have %q | err:%v", values, err)
 	}
 }
 
-func TestCutInput_WithSingleQuotes_ReturnArrWithOneItem(t *testing.T) {
-	expected := []string{
-		"value1 value2",
-	}
-
-	var values []string
-	var leng int
-
-	if values, leng = cutInput(`'value1 value2'`); len(values) != 1 && leng != 1 {
-		t.Fatalf("expected arr with one item and 1 leng for valid string, but have arr len:%d | returned len:%d", len(values), leng)
+func TestTokenize_WithSingleQuotes_ReturnArrWithOneItem(t *testing.T) {
+	tokens, err := tokenize(`'value1 value2'`)
+	if values := tokenValues(tokens); err != nil || !reflect.DeepEqual(values, []string{"value1 value2"}) {
+		t.Fatalf("expected arr with item 'value1 value2', but have %q | err:%v", values, err)
 	}
+}
 
-	for i, value := range expected {
-		if values[i] != value {
-			t.Fatalf("expected item %s at position %d , but have %s", value, i, values[i])
+func TestTokenize_WithShellGrammar_ReturnsWords(t *testing.T) {
+	cases := map[string][]string{
+		"a\tb\n\tc":         {"a", "b", "c"},
+		`--name="a b"c`:     {"--name=a bc"},
+		`"it's" 'say "hi"'`: {"it's", `say "hi"`},
+		`a\ b \"q\" \\`:     {"a b", `"q"`, `\`},
+		`"a\"b\\c\d\$"`:     {`a"b\c\d$`},
+		`'no \escape'`:      {`no \escape`},
+		"one\\\ntwo":        {"onetwo"},
+		`'' ""x`:            {"", "x"},
+		`--name='a'"b"\ c`:  {"--name=ab c"},
+	}
+
+	for input, want := range cases {
+		tokens, err := tokenize(input)
+		if err != nil {
+			t.Errorf("tokenize(%q) unexpected error: %v", input, err)
+			continue
+		}
+		if got := tokenValues(tokens); !reflect.DeepEqual(got, want) {
+			t.Errorf("tokenize(%q) = %q, want %q", input, got, want)
 		}
 	}
 }
 
-// --- RemoveFirst ---
-
-func TestRemoveFirst_WithEmptySlice_ReturnsEmptySlice(t *testing.T) {
-	input := []int{}
-	got := removeFirst(input, 1)
-	want := []int{}
-	if !reflect.DeepEqual(got, want) {
-		t.Fatalf("expected %v, got %v", want, got)
+func TestTokenize_WithUnbalancedQuotes_ReturnsErrorWithColumn(t *testing.T) {
+	cases := map[string]string{
+		`cmd --name="abc`: "Parsing err: unterminated double quote at column 12",
+		`cmd 'it`:         "Parsing err: unterminated single quote at column 5",
+		`cmd "it's`:       "Parsing err: unterminated double quote at column 5",
+		`cmd "ок" 'x`:     "Parsing err: unterminated single quote at column 10",
+		`cmd trailing\`:   "Parsing err: unfinished escape at column 13",
 	}
-}
 
-func TestRemoveFirst_WithCountGreaterThanLength_ReturnsOriginalSlice(t *testing.T) {
-	input := []int{1, 2, 3}
-	got := removeFirst(input, 5)
-	want := []int{1, 2, 3}
-	if !reflect.DeepEqual(got, want) {
-		t.Fatalf("expected %v, got %v", want, got)
+	for input, want := range cases {
+		_, err := tokenize(input)
+		if err == nil || err.Error() != want {
+			t.Errorf("tokenize(%q) error = %v, want %q", input, err, want)
+		}
 	}
 }
 
-func TestRemoveFirst_WithCountEqualLength_ReturnsEmptySlice(t *testing.T) {
-	input := []int{1, 2, 3}
-	got := removeFirst(input, 3)
-	want := []int{}
-	if !reflect.DeepEqual(got, want) {
-		t.Fatalf("expected %v, got %v", want, got)
+func TestTokenize_ReturnsByteOffsets(t *testing.T) {
+	tokens, err := tokenize(`cmd  --name="a b"`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
-}
 
-func TestRemoveFirst_WithCountLessThanLength_ReturnsRemainingSlice(t *testing.T) {
-	input := []int{1, 2, 3, 4, 5}
-	got := removeFirst(input, 2)
-	want := []int{3, 4, 5}
-	if !reflect.DeepEqual(got, want) {
-		t.Fatalf("expected %v, got %v", want, got)
+	want := []token{{value: "cmd", start: 0, end: 3}, {value: "--name=a b", start: 5, end: 17}}
+	if !reflect.DeepEqual(tokens, want) {
+		t.Fatalf("expected %v, got %v", want, tokens)
 	}
 }
@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+)
+
+func Quote(word string) string {
+	if word == "" {
+		return "''"
+	}
+	if name, value, found := strings.Cut(word, "="); found && isFlag(name) && isSafeWord(name) {
+		return name + "=" + Quote(value)
+	}
+	if isSafeWord(word) {
+		return word
+	}
+	return "'" + strings.ReplaceAll(word, "'", `'\''`) + "'"
+}
+
+func JoinArgs(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		quoted = append(quoted, Quote(arg))
+	}
+	return strings.Join(quoted, " ")
+}
+
+func isSafeWord(word string) bool {
+	if strings.HasPrefix(word, "~") {
+		return false
+	}
+	return strings.IndexFunc(word, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("-_./:=@%+,~", r)
+	}) < 0
+}
+
+func (input ParsedInput) Args() []string {
+	args := append([]string{input.Command}, input.Subcommands...)
+	for _, flag := range input.InputFlags {
+		if flag.HaveInputValue() {
+			args = append(args, "--"+flag.Name+"="+flag.Value)
+		} else {
+			args = append(args, "--"+flag.Name)
+		}
+	}
+	return args
+}
+
+func (input ParsedInput) String() string {
+	return JoinArgs(input.Args())
+}
@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQuote_ReturnsShellSafeWords(t *testing.T) {
+	cases := map[string]string{
+		"deploy":          "deploy",
+		"":                "''",
+		"a b":             "'a b'",
+		"it's":            `'it'\''s'`,
+		"--name=a b":      "--name='a b'",
+		"--path=/tmp/x.y": "--path=/tmp/x.y",
+		"$HOME":           "'$HOME'",
+		"~/x":             "'~/x'",
+		"--a b=c":         "'--a b=c'",
+		"файл":            "файл",
+	}
+	for input, want := range cases {
+		if got := Quote(input); got != want {
+			t.Errorf("Quote(%q) = %s, want %s", input, got, want)
+		}
+	}
+}
+
+func TestParsedInput_String_RoundTripsThroughParseInput(t *testing.T) {
+	inputs := []*ParsedInput{
+		{
+			Command:     "deploy",
+			Subcommands: []string{"app", "with space", ""},
+			InputFlags: []InputFlag{
+				{Name: "message", Value: `it's "done" $now`},
+				{Name: "quoted", Value: `"x"`},
+				{Name: "padded", Value: "  a\tb\n "},
+				{Name: "verbose"},
+				{Name: "tag", Value: "a=b"},
+				{Name: "tag", Value: `back\slash`},
+			},
+		},
+		{Command: "run", Subcommands: []string{}, InputFlags: []InputFlag{}},
+	}
+
+	for _, input := range inputs {
+		parsed, err := ParseInput(input.String())
+		if err != nil {
+			t.Fatalf("ParseInput(%s) unexpected error: %v", input, err)
+		}
+		if !reflect.DeepEqual(parsed, input) {
+			t.Fatalf("round trip of %s\nexpected %+v\ngot      %+v", input, input, parsed)
+		}
+	}
+}
+
+func TestParsedInput_Args_RoundTripsThroughParseArgs(t *testing.T) {
+	input := &ParsedInput{
+		Command:     "db",
+		Subcommands: []string{"migrate"},
+		InputFlags:  []InputFlag{{Name: "dsn", Value: "postgres://u@h/db?x=1"}, {Name: "dry-run"}},
+	}
+
+	want := []string{"db", "migrate", "--dsn=postgres://u@h/db?x=1", "--dry-run"}
+	if got := input.Args(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	parsed, err := ParseArgs(input.Args())
+	if err != nil || !reflect.DeepEqual(parsed, input) {
+		t.Fatalf("expected %+v, got %+v (%v)", input, parsed, err)
+	}
+	if got := input.String(); got != "db migrate --dsn='postgres://u@h/db?x=1' --dry-run" {
+		t.Fatalf("unexpected string %s", got)
+	}
+}
@@ -0,0 +1,93 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const responseFilePrefix = "@"
+
+type ParseOptions struct {
+	ResponseFiles   bool
+	WorkDir         string
+	Variables       VariableLookup
+	StrictVariables bool
+	Comments        bool
+}
+
+func ParseArgsWithOptions(args []string, options ParseOptions) (*ParsedInput, error) {
+	args = unquoteArgs(args)
+	if options.ResponseFiles {
+		expanded, err := ExpandResponseFiles(args, options.WorkDir)
+		if err != nil {
+			return nil, err
+		}
+		args = expanded
+	}
+	return ParseArgs(args)
+}
+
+func ExpandResponseFiles(args []string, workDir string) ([]string, error) {
+	expanded := make([]string, 0, len(args))
+	for _, arg := range args {
+		words, err := expandResponseArg(arg, workDir, nil)
+		if err != nil {
+			return nil, fmt.Errorf("Parsing err: %w", err)
+		}
+		expanded = append(expanded, words...)
+	}
+	return expanded, nil
+}
+
+func expandResponseArg(arg, dir string, stack []string) ([]string, error) {
+	if strings.HasPrefix(arg, responseFilePrefix+responseFilePrefix) {
+		return []string{arg[1:]}, nil
+	}
+	if !strings.HasPrefix(arg, responseFilePrefix) || arg == responseFilePrefix {
+		return []string{arg}, nil
+	}
+	return readResponseFile(arg[1:], dir, stack)
+}
+
+func readResponseFile(name, dir string, stack []string) ([]string, error) {
+	path := name
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	if slices.Contains(stack, path) {
+		return nil, fmt.Errorf("response file %s includes itself (%s)", name, strings.Join(append(stack, path), " -> "))
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't read response file %s: %w", name, err)
+	}
+	stack = append(stack, path)
+
+	args := make([]string, 0)
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		tokens, err := tokenize(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %s", name, i+1, strings.TrimPrefix(err.Error(), "Parsing err: "))
+		}
+		for _, tok := range tokens {
+			expanded, err := expandResponseArg(tok.value, filepath.Dir(path), stack)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", name, i+1, err)
+			}
+			args = append(args, expanded...)
+		}
+	}
+	return args, nil
+}
@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeResponseFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseArgsWithOptions_WithResponseFiles_ExpandsRecursively(t *testing.T) {
+	dir := t.TempDir()
+	writeResponseFile(t, dir, "args.txt", "# deployment flags\nsubcommand\n\n--name='hello world' --count=3\n@nested/more.txt\n")
+	writeResponseFile(t, dir, "nested/more.txt", "--tag=@@latest\n--verbose\n")
+
+	parsedInput, err := ParseArgsWithOptions([]string{"command", "@args.txt", "--last"}, ParseOptions{ResponseFiles: true, WorkDir: dir})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := ParsedInput{
+		Command:     "command",
+		Subcommands: []string{"subcommand"},
+		InputFlags: []InputFlag{
+			{Name: "name", Value: "hello world"},
+			{Name: "count", Value: "3"},
+			{Name: "tag", Value: "@@latest"},
+			{Name: "verbose", Value: ""},
+			{Name: "last", Value: ""},
+		},
+	}
+	if !reflect.DeepEqual(*parsedInput, want) {
+		t.Fatalf("expected %v, got %v", want, *parsedInput)
+	}
+}
+
+func TestParseArgsWithOptions_WithResponseFiles_KeepsQuotedWords(t *testing.T) {
+	dir := t.TempDir()
+	writeResponseFile(t, dir, "args.txt", `--name="'quoted'" --pad=" x "`+"\n")
+
+	parsedInput, err := ParseArgsWithOptions([]string{"command", "@args.txt"}, ParseOptions{ResponseFiles: true, WorkDir: dir})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []InputFlag{{Name: "name", Value: "'quoted'"}, {Name: "pad", Value: " x "}}
+	if !reflect.DeepEqual(parsedInput.InputFlags, want) {
+		t.Fatalf("expected %v, got %v", want, parsedInput.InputFlags)
+	}
+}
+
+func TestParseArgsWithOptions_WithoutOptIn_KeepsAtTokens(t *testing.T) {
+	parsedInput, err := ParseArgsWithOptions([]string{"command", "@args.txt"}, ParseOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(parsedInput.Subcommands, []string{"@args.txt"}) {
+		t.Fatalf("expected @args.txt to stay a subcommand, got %v", parsedInput.Subcommands)
+	}
+}
+
+func TestExpandResponseFiles_WithEscapedAt_ReturnsLiteral(t *testing.T) {
+	args, err := ExpandResponseFiles([]string{"command", "@@user"}, t.TempDir())
+	if err != nil || !reflect.DeepEqual(args, []string{"command", "@user"}) {
+		t.Fatalf("unexpected result %v, %v", args, err)
+	}
+}
+
+func TestExpandResponseFiles_Errors_ReferenceFileAndLine(t *testing.T) {
+	dir := t.TempDir()
+	writeResponseFile(t, dir, "a.txt", "--one\n@b.txt\n")
+	writeResponseFile(t, dir, "b.txt", "# loops back\n@a.txt\n")
+	writeResponseFile(t, dir, "broken.txt", "--one\n--two\n@missing.txt\n")
+
+	cases := []struct {
+		arg  string
+		want string
+	}{
+		{"@a.txt", "a.txt:2: b.txt:2: response file a.txt includes itself"},
+		{"@broken.txt", "broken.txt:3: can't read response file missing.txt"},
+		{"@none.txt", "can't read response file none.txt"},
+	}
+
+	for _, tc := range cases {
+		_, err := ExpandResponseFiles([]string{"command", tc.arg}, dir)
+		if err == nil || !strings.HasPrefix(err.Error(), "Parsing err: ") || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.arg, tc.want, err)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ChainOperator int
+
+const (
+	ThenOperator ChainOperator = iota
+	AndOperator
+	OrOperator
+)
+
+func (op ChainOperator) String() string {
+	switch op {
+	case AndOperator:
+		return "&&"
+	case OrOperator:
+		return "||"
+	}
+	return ";"
+}
+
+type SequenceStep struct {
+	Operator ChainOperator
+	Input    *ParsedInput
+}
+
+type Sequence []SequenceStep
+
+func ParseSequence(input string) (Sequence, error) {
+	return ParseSequenceWithOptions(input, ParseOptions{})
+}
+
+func ParseSequenceWithOptions(input string, options ParseOptions) (Sequence, error) {
+	if !validateInput(input) {
+		return nil, fmt.Errorf("Parsing err: empty or only whitespace in string")
+	}
+
+	tokens, err := tokenizeWith(input, options.expander(), true, false, options.Comments)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := make(Sequence, 0)
+	operator := ThenOperator
+	from := 0
+	for i := 0; i <= len(tokens); i++ {
+		if i < len(tokens) && !tokens[i].operator {
+			continue
+		}
+		if from == i {
+			if i < len(tokens) {
+				return nil, tokenError(fmt.Sprintf("Parsing err: missing command before %s", tokens[i].value), input, tokens, i)
+			}
+			if len(steps) == 0 {
+				return nil, fmt.Errorf("Parsing err: empty or only whitespace in string")
+			}
+			if operator != ThenOperator {
+				return nil, tokenError(fmt.Sprintf("Parsing err: missing command after %s", operator), input, tokens, i-1)
+			}
+			break
+		}
+
+		parsed, err := parseTokens(input, tokens, from, i)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, SequenceStep{Operator: operator, Input: parsed})
+
+		if i < len(tokens) {
+			operator = chainOperator(tokens[i].value)
+		}
+		from = i + 1
+	}
+	return steps, nil
+}
+
+func CompletionWords(line string) []string {
+	tokens, _ := tokenizeWith(line, nil, true, true, false)
+	words := make([]string, 0)
+	for _, tok := range tokens {
+		if tok.operator {
+			words = words[:0]
+			continue
+		}
+		words = append(words, tok.value)
+	}
+
+	if len(tokens) == 0 || tokens[len(tokens)-1].operator || tokens[len(tokens)-1].end < len(line) {
+		words = append(words, "")
+	}
+	return words
+}
+
+func MaskFlagValues(line string, mask string, secret func(step int, name string) bool) (string, error) {
+	tokens, err := tokenizeWith(line, nil, true, false, false)
+	if err != nil {
+		return "", err
+	}
+
+	var masked strings.Builder
+	last, step := 0, 0
+	for _, tok := range tokens {
+		if tok.operator {
+			step++
+			continue
+		}
+		if !isFlag(tok.value) && !isShortFlag(tok.value) {
+			continue
+		}
+		name, value, _ := strings.Cut(strings.TrimLeft(tok.value, "-"), "=")
+		if value == "" || !secret(step, name) {
+			continue
+		}
+
+		raw := line[tok.start:tok.end]
+		prefix, value, _ := strings.Cut(raw, "=")
+		if strings.ContainsAny(prefix, "'\"\\") {
+			prefix, _, _ = strings.Cut(tok.value, "=")
+		}
+		replacement := mask
+		if len(value) >= 2 && (value[0] == '\'' || value[0] == '"') && value[len(value)-1] == value[0] {
+			replacement = value[:1] + mask + value[:1]
+		}
+		masked.WriteString(line[last:tok.start] + prefix + "=" + replacement)
+		last = tok.end
+	}
+	masked.WriteString(line[last:])
+	return masked.String(), nil
+}
+
+func (steps Sequence) String() string {
+	var line strings.Builder
+	for i, step := range steps {
+		if i > 0 {
+			if step.Operator == ThenOperator {
+				line.WriteString("; ")
+			} else {
+				line.WriteString(" " + step.Operator.String() + " ")
+			}
+		}
+		line.WriteString(step.Input.String())
+	}
+	return line.String()
+}
+
+func chainOperator(value string) ChainOperator {
+	switch value {
+	case "&&":
+		return AndOperator
+	case "||":
+		return OrOperator
+	}
+	return ThenOperator
+}
+
+func chainOperatorAt(str string, pos int) string {
+	for _, op := range []string{"&&", "||", ";"} {
+		if strings.HasPrefix(str[pos:], op) {
+			return op
+		}
+	}
+	return ""
+}
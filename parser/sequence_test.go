@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseSequence_SplitsOnOperators(t *testing.T) {
+	steps, err := ParseSequence(`build --target=app&&test || echo 'a && b' ; echo "x;y" \; done;`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := Sequence{
+		{Operator: ThenOperator, Input: &ParsedInput{Command: "build", Subcommands: []string{}, InputFlags: []InputFlag{{Name: "target", Value: "app"}}}},
+		{Operator: AndOperator, Input: NewParserInput("test")},
+		{Operator: OrOperator, Input: &ParsedInput{Command: "echo", Subcommands: []string{"a && b"}, InputFlags: []InputFlag{}}},
+		{Operator: ThenOperator, Input: &ParsedInput{Command: "echo", Subcommands: []string{"x;y", ";", "done"}, InputFlags: []InputFlag{}}},
+	}
+	if !reflect.DeepEqual(steps, want) {
+		t.Fatalf("expected %+v, got %+v", want, steps)
+	}
+
+	if got := steps.String(); got != `build --target=app && test || echo 'a && b'; echo 'x;y' ';' done` {
+		t.Fatalf("unexpected string %s", got)
+	}
+	again, err := ParseSequence(steps.String())
+	if err != nil || !reflect.DeepEqual(again, steps) {
+		t.Fatalf("round trip failed: %+v (%v)", again, err)
+	}
+}
+
+func TestParseSequence_WithMisplacedOperators_ReturnsPositionedError(t *testing.T) {
+	cases := map[string]struct {
+		msg    string
+		offset int
+	}{
+		"&& build":       {"Parsing err: missing command before &&", 0},
+		"build ; ; test": {"Parsing err: missing command before ;", 8},
+		"build &&":       {"Parsing err: missing command after &&", 6},
+		"build | grep x": {"Parsing err: unsupported operator | at column 7", 6},
+		"build & ":       {"Parsing err: unsupported operator & at column 7", 6},
+		"build ; --x":    {"First word must be command , not flag --x", 8},
+	}
+
+	for input, want := range cases {
+		_, err := ParseSequence(input)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("ParseSequence(%q) expected ParseError, got %v", input, err)
+			continue
+		}
+		if parseErr.Error() != want.msg || parseErr.Offset != want.offset {
+			t.Errorf("ParseSequence(%q) = %q at %d, want %q at %d", input, parseErr.Error(), parseErr.Offset, want.msg, want.offset)
+		}
+	}
+}
+
+func TestParseInput_KeepsOperatorsLiteral(t *testing.T) {
+	parsed, err := ParseInput("echo a;b && c")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(parsed.Subcommands, []string{"a;b", "&&", "c"}) {
+		t.Fatalf("unexpected subcommands %q", parsed.Subcommands)
+	}
+}
+
+func TestCompletionWords_ReturnsWordsOfLastCommand(t *testing.T) {
+	cases := map[string][]string{
+		"":                        {""},
+		"build --fl":              {"build", "--fl"},
+		"build ":                  {"build", ""},
+		`echo "a;b" --fl`:         {"echo", "a;b", "--fl"},
+		"build && test --na":      {"test", "--na"},
+		"build ||":                {""},
+		`deploy --msg="half done`: {"deploy", "--msg=half done"},
+		"run a & b":               {"run", "a", "&", "b"},
+		`run c:\`:                 {"run", "c:"},
+	}
+	for line, want := range cases {
+		if got := CompletionWords(line); !reflect.DeepEqual(got, want) {
+			t.Errorf("CompletionWords(%q) = %q, want %q", line, got, want)
+		}
+	}
+}
+
+func TestMaskFlagValues_MasksOnlySecretPositions(t *testing.T) {
+	secret := func(step int, name string) bool {
+		return step == 1 && (name == "password" || name == "p")
+	}
+	cases := map[string]string{
+		"login --user=hunter2 --password=hunter2":              "login --user=hunter2 --password=hunter2",
+		"version && login --user=hunter2 --password=hunter2":   "version && login --user=hunter2 --password=***",
+		"version; login -p='s3cret pass' --password=\"a b\" x": "version; login -p='***' --password=\"***\" x",
+		`version || "--password=s3cret"`:                       `version || --password=***`,
+	}
+	for line, want := range cases {
+		got, err := MaskFlagValues(line, "***", secret)
+		if err != nil || got != want {
+			t.Errorf("MaskFlagValues(%q) = %q, %v, want %q", line, got, err, want)
+		}
+	}
+	if _, err := MaskFlagValues(`login --password="open`, "***", secret); err == nil {
+		t.Errorf("expected error for unterminated quote")
+	}
+}
+
+func TestParseSequenceWithOptions_WithComments_SkipsToLineEnd(t *testing.T) {
+	steps, err := ParseSequenceWithOptions("build a#b '#c' # note && test\n--fast", ParseOptions{Comments: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &ParsedInput{Command: "build", Subcommands: []string{"a#b", "#c"}, InputFlags: []InputFlag{{Name: "fast"}}}
+	if len(steps) != 1 || !reflect.DeepEqual(steps[0].Input, want) {
+		t.Fatalf("unexpected steps %v", steps)
+	}
+}
@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+type VariableLookup func(name string) (string, bool)
+
+func MapLookup(variables map[string]string) VariableLookup {
+	return func(name string) (string, bool) {
+		value, found := variables[name]
+		return value, found
+	}
+}
+
+func ChainLookup(lookups ...VariableLookup) VariableLookup {
+	return func(name string) (string, bool) {
+		for _, lookup := range lookups {
+			if value, found := lookup(name); found {
+				return value, true
+			}
+		}
+		return "", false
+	}
+}
+
+func ParseInputWithOptions(input string, options ParseOptions) (*ParsedInput, error) {
+	return parseInput(input, options.expander())
+}
+
+func (options ParseOptions) expander() *expander {
+	if options.Variables == nil {
+		return nil
+	}
+	return &expander{lookup: options.Variables, strict: options.StrictVariables}
+}
+
+type expander struct {
+	lookup VariableLookup
+	strict bool
+}
+
+func (vars *expander) expand(str string, pos int, tokenIndex int) (string, int, error) {
+	if str[pos+1] != '{' {
+		end := pos + 1
+		for end < len(str) && isVariableRune(str[end], end > pos+1) {
+			end++
+		}
+		return vars.value(str, str[pos+1:end], pos, end, tokenIndex)
+	}
+
+	end := matchingBrace(str, pos+2)
+	if end < 0 {
+		return "", 0, columnError("unterminated variable", str, tokenIndex, pos, len(str))
+	}
+	end++
+
+	body := str[pos+2 : end-1]
+	name, _, hasFallback := strings.Cut(body, ":-")
+	if !isVariableName(name) {
+		return "", 0, columnError(fmt.Sprintf("bad substitution ${%s}", body), str, tokenIndex, pos, end)
+	}
+	if !hasFallback {
+		return vars.value(str, name, pos, end, tokenIndex)
+	}
+
+	if value, found := vars.lookup(name); found && value != "" {
+		return value, end, nil
+	}
+	fallback, err := vars.expandRange(str, pos+2+len(name)+2, end-1, tokenIndex)
+	return fallback, end, err
+}
+
+func (vars *expander) value(str, name string, pos int, end int, tokenIndex int) (string, int, error) {
+	value, found := vars.lookup(name)
+	if !found && vars.strict {
+		return "", 0, columnError(fmt.Sprintf("variable %s is not set", name), str, tokenIndex, pos, end)
+	}
+	return value, end, nil
+}
+
+func (vars *expander) expandRange(str string, from int, to int, tokenIndex int) (string, error) {
+	var buf strings.Builder
+	for i := from; i < to; {
+		if str[i] != '$' || !isVariableStart(str[i+1:to]) {
+			buf.WriteByte(str[i])
+			i++
+			continue
+		}
+		value, end, err := vars.expand(str, i, tokenIndex)
+		if err != nil {
+			return "", err
+		}
+		buf.WriteString(value)
+		i = end
+	}
+	return buf.String(), nil
+}
+
+func matchingBrace(str string, from int) int {
+	depth := 0
+	for i := from; i < len(str); i++ {
+		switch {
+		case strings.HasPrefix(str[i:], "${"):
+			depth++
+			i++
+		case str[i] == '}' && depth == 0:
+			return i
+		case str[i] == '}':
+			depth--
+		}
+	}
+	return -1
+}
+
+func isVariableStart(rest string) bool {
+	return rest != "" && (rest[0] == '{' || isVariableRune(rest[0], false))
+}
+
+func isVariableName(name string) bool {
+	for i := 0; i < len(name); i++ {
+		if !isVariableRune(name[i], i > 0) {
+			return false
+		}
+	}
+	return name != ""
+}
+
+func isVariableRune(c byte, digits bool) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || digits && c >= '0' && c <= '9'
+}
@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func expandOptions(strict bool) ParseOptions {
+	return ParseOptions{
+		Variables: ChainLookup(
+			MapLookup(map[string]string{"NAME": "repl", "EMPTY": ""}),
+			MapLookup(map[string]string{"HOME": "/home/me", "NAME": "env", "SPACED": "a b"}),
+		),
+		StrictVariables: strict,
+	}
+}
+
+func TestParseInputWithOptions_ExpandsVariables(t *testing.T) {
+	cases := map[string][]string{
+		`cd $HOME/src`:                 {"cd", "/home/me/src"},
+		`echo ${NAME}x "$NAME y"`:      {"echo", "replx", "repl y"},
+		`echo '$HOME' \$HOME "\$HOME"`: {"echo", "$HOME", "$HOME", "$HOME"},
+		`echo ${MISSING:-dflt}`:        {"echo", "dflt"},
+		`echo ${EMPTY:-$HOME/x}`:       {"echo", "/home/me/x"},
+		`echo ${MISSING:-${NAME}}`:     {"echo", "repl"},
+		`echo $SPACED`:                 {"echo", "a b"},
+		`echo $MISSING end`:            {"echo", "end"},
+		`echo "$MISSING" $ 5$`:         {"echo", "", "$", "5$"},
+	}
+
+	for input, want := range cases {
+		parsed, err := ParseInputWithOptions(input, expandOptions(false))
+		if err != nil {
+			t.Errorf("ParseInputWithOptions(%q) unexpected error: %v", input, err)
+			continue
+		}
+		if got := append([]string{parsed.Command}, parsed.Subcommands...); !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseInputWithOptions(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestParseInputWithOptions_ExpandsFlagValues(t *testing.T) {
+	parsed, err := ParseInputWithOptions(`deploy --dir=$HOME --user="${USER:-admin}"`, expandOptions(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []InputFlag{{Name: "dir", Value: "/home/me"}, {Name: "user", Value: "admin"}}
+	if !reflect.DeepEqual(parsed.InputFlags, want) {
+		t.Fatalf("expected %v, got %v", want, parsed.InputFlags)
+	}
+}
+
+func TestParseInputWithOptions_InStrictMode_RejectsUnsetVariables(t *testing.T) {
+	_, err := ParseInputWithOptions("deploy --dir=$NOPE", expandOptions(true))
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected ParseError, got %T: %v", err, err)
+	}
+	if parseErr.Error() != "Parsing err: variable NOPE is not set at column 14" || parseErr.Token != 1 {
+		t.Fatalf("unexpected error %+v", parseErr)
+	}
+	if got, want := parseErr.Caret(), "deploy --dir=$NOPE\n             ^~~~~"; got != want {
+		t.Fatalf("expected caret\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestParseInputWithOptions_WithMalformedVariables_ReturnsError(t *testing.T) {
+	cases := map[string]string{
+		"echo ${HOME":   "Parsing err: unterminated variable at column 6",
+		"echo ${}":      "Parsing err: bad substitution ${} at column 6",
+		"echo ${1x:-a}": "Parsing err: bad substitution ${1x:-a} at column 6",
+	}
+
+	for input, want := range cases {
+		_, err := ParseInputWithOptions(input, expandOptions(false))
+		if err == nil || err.Error() != want {
+			t.Errorf("ParseInputWithOptions(%q) error = %v, want %q", input, err, want)
+		}
+	}
+}
+
+func TestParseInput_WithoutVariables_KeepsDollarLiteral(t *testing.T) {
+	parsed, err := ParseInput("echo $HOME ${X}")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(parsed.Subcommands, []string{"$HOME", "${X}"}) {
+		t.Fatalf("unexpected subcommands %q", parsed.Subcommands)
+	}
+}
@@ -0,0 +1,132 @@
+package router
+
+import (
+	"sort"
+	"strings"
+)
+
+func (r *Router) Complete(words []string) []string {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	prefix := words[len(words)-1]
+
+	var point RoutePoint
+	for i, word := range words[:len(words)-1] {
+		if strings.HasPrefix(word, "--") {
+			break
+		}
+
+		var exist bool
+		if i == 0 {
+			point, exist = r.points[word]
+		} else if cmd, ok := point.(*CmdPoint); ok {
+			point, exist = cmd.GetSubCommand(word)
+		}
+		if !exist {
+			return []string{}
+		}
+	}
+
+	if point == nil {
+		return completeNames(r.points, prefix)
+	}
+
+	switch p := point.(type) {
+	case *CmdPoint:
+		return completeNames(p.GetAllSubCommands(), prefix)
+	case *EndPoint:
+		return p.completeOption(prefix, r.workDir)
+	}
+	return []string{}
+}
+
+func completeNames(points map[string]RoutePoint, prefix string) []string {
+	candidates := make([]string, 0)
+	for name := range points {
+		if strings.HasPrefix(name, prefix) {
+			candidates = append(candidates, name)
+		}
+	}
+	sort.Strings(candidates)
+	return candidates
+}
+
+func (endPoint *EndPoint) completeOption(prefix string, workDir string) []string {
+	options := endPoint.allOptions()
+	candidates := make([]string, 0)
+
+	if name, value, found := strings.Cut(strings.TrimPrefix(prefix, "--"), "="); found && strings.HasPrefix(prefix, "--") {
+		option, exist := options[name]
+		if !exist {
+			return candidates
+		}
+		for _, candidate := range completeOptionValue(option, value, workDir) {
+			candidates = append(candidates, "--"+name+"="+candidate)
+		}
+		sort.Strings(candidates)
+		return candidates
+	}
+
+	for _, name := range sortedOptionNames(options) {
+		usage := "--" + name
+		if optionPlaceholder(options[name].Type) != "" {
+			usage += "="
+		}
+		if strings.HasPrefix(usage, prefix) {
+			candidates = append(candidates, usage)
+		}
+		if file := "--" + name + secretFileSuffix + "="; options[name].Secret && strings.HasPrefix(file, prefix) {
+			candidates = append(candidates, file)
+		}
+		if negated := "--" + negationPrefix + name; options[name].Negatable && strings.HasPrefix(negated, prefix) {
+			candidates = append(candidates, negated)
+		}
+	}
+	return candidates
+}
+
+func completeOptionValue(option Option, prefix string, workDir string) []string {
+	if isPathOptionType(option.Type) {
+		return completePath(option.Type, prefix, workDir)
+	}
+	if option.Type == Enum {
+		return completeEnum(option, prefix)
+	}
+
+	def, exist := LookupOptionType(option.Type)
+	if !exist || def.Complete == nil {
+		return []string{}
+	}
+
+	candidates := make([]string, 0)
+	for _, candidate := range def.Complete(prefix) {
+		if strings.HasPrefix(candidate, prefix) {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates
+}
+
+func (endPoint *EndPoint) allOptions() map[string]Option {
+	options := make(map[string]Option)
+	for name, option := range endPoint.options {
+		options[name] = option
+	}
+
+	collectGroupOptions(endPoint.groups.groups, options)
+	return options
+}
+
+func collectGroupOptions(groups map[string]OptionsGroup, options map[string]Option) {
+	for _, group := range groups {
+		trigger := strings.TrimPrefix(group.Triger, "--")
+		if _, exist := options[trigger]; !exist {
+			options[trigger] = NewOption(trigger, Bool, false)
+		}
+		for name, option := range group.Options {
+			options[name] = option
+		}
+		collectGroupOptions(group.Groups, options)
+	}
+}
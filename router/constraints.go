@@ -0,0 +1,140 @@
+package router
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"strconv"
+	"unicode/utf8"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+)
+
+func validateOption(option Option, context ctx.Context) error {
+	if err := optionTypeValidation(option, context); err != nil {
+		return maskSecretError(option, context, err)
+	}
+	if isCollectionOptionType(option.Type) {
+		return nil
+	}
+	return maskSecretError(option, context, validateConstraints(option, context))
+}
+
+func validateConstraints(option Option, context ctx.Context) error {
+	value, _ := context.GetValueAsString(option.Name)
+
+	if option.Min != nil {
+		order, err := compareNumber(option, value, context, *option.Min)
+		if err != nil {
+			return fmt.Errorf("Routing error: Option %s value \"%s\" isn't a number: %s", option.Name, value, err.Error())
+		}
+		if order < 0 {
+			return fmt.Errorf("Routing error: Option %s value %s violates min %s", option.Name, value, formatBound(*option.Min))
+		}
+	}
+	if option.Max != nil {
+		order, err := compareNumber(option, value, context, *option.Max)
+		if err != nil {
+			return fmt.Errorf("Routing error: Option %s value \"%s\" isn't a number: %s", option.Name, value, err.Error())
+		}
+		if order > 0 {
+			return fmt.Errorf("Routing error: Option %s value %s violates max %s", option.Name, value, formatBound(*option.Max))
+		}
+	}
+
+	length := utf8.RuneCountInString(value)
+	if option.MinLength > 0 && length < option.MinLength {
+		return fmt.Errorf("Routing error: Option %s value \"%s\" violates min length %d", option.Name, value, option.MinLength)
+	}
+	if option.MaxLength > 0 && length > option.MaxLength {
+		return fmt.Errorf("Routing error: Option %s value \"%s\" violates max length %d", option.Name, value, option.MaxLength)
+	}
+	if option.Pattern != nil && !option.Pattern.MatchString(value) {
+		return fmt.Errorf("Routing error: Option %s value \"%s\" violates pattern %s", option.Name, value, option.Pattern)
+	}
+
+	for _, validator := range option.Validators {
+		if err := validator(value); err != nil {
+			return fmt.Errorf("Routing error: Option %s value \"%s\" violates validator: %s", option.Name, value, err.Error())
+		}
+	}
+	return nil
+}
+
+func compareNumber(option Option, value string, context ctx.Context, bound float64) (int, error) {
+	switch option.Type {
+	case Count:
+		return compareInt(int64(context.GetValueAsCount(option.Name)), bound), nil
+	case Float:
+		number, err := strconv.ParseFloat(value, 64)
+		return cmp.Compare(number, bound), err
+	case Int, Int8, Int16, Int32, Int64:
+		number, err := ctx.ParseInt(value, 64)
+		return compareInt(number, bound), err
+	case Uint, Uint8, Uint16, Uint32, Uint64:
+		number, err := ctx.ParseUint(value, 64)
+		return compareUint(number, bound), err
+	}
+	return 0, fmt.Errorf("type %s has no numeric range", option.Type)
+}
+
+func compareInt(number int64, bound float64) int {
+	switch {
+	case bound >= math.MaxInt64:
+		return -1
+	case bound < math.MinInt64:
+		return 1
+	}
+	floor := math.Floor(bound)
+	if order := cmp.Compare(number, int64(floor)); order != 0 || floor == bound {
+		return order
+	}
+	return -1
+}
+
+func compareUint(number uint64, bound float64) int {
+	switch {
+	case bound < 0:
+		return 1
+	case bound >= math.MaxUint64:
+		return -1
+	}
+	floor := math.Floor(bound)
+	if order := cmp.Compare(number, uint64(floor)); order != 0 || floor == bound {
+		return order
+	}
+	return -1
+}
+
+func isNumericOptionType(optionType OptionType) bool {
+	return isIntegerOptionType(optionType) || optionType == Float || optionType == Count
+}
+
+func formatBound(bound float64) string {
+	if bound == math.Trunc(bound) {
+		return strconv.FormatFloat(bound, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(bound, 'f', -1, 64)
+}
+
+func optionConstraints(option Option) []string {
+	constraints := make([]string, 0)
+	switch {
+	case option.Min != nil && option.Max != nil:
+		constraints = append(constraints, fmt.Sprintf("range: %s..%s", formatBound(*option.Min), formatBound(*option.Max)))
+	case option.Min != nil:
+		constraints = append(constraints, "min: "+formatBound(*option.Min))
+	case option.Max != nil:
+		constraints = append(constraints, "max: "+formatBound(*option.Max))
+	}
+	if option.MinLength > 0 {
+		constraints = append(constraints, fmt.Sprintf("min length: %d", option.MinLength))
+	}
+	if option.MaxLength > 0 {
+		constraints = append(constraints, fmt.Sprintf("max length: %d", option.MaxLength))
+	}
+	if option.Pattern != nil {
+		constraints = append(constraints, "pattern: "+option.Pattern.String())
+	}
+	return constraints
+}
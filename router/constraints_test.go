@@ -0,0 +1,128 @@
+package router
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+)
+
+func routeConstrainedInput(t *testing.T, input string) error {
+	t.Helper()
+	r := NewRouter()
+	r.Endpoint("compress").
+		IntOption("level").
+		Range("level", 1, 9).
+		FloatOption("ratio").
+		Min("ratio", 0.5).
+		StringOption("name").
+		MinLength("name", 3).
+		MaxLength("name", 8).
+		Pattern("name", "^[a-z]+$").
+		StringOption("owner").
+		Validate("owner", func(value string) error {
+			if value == "root" {
+				return errors.New("root is not allowed")
+			}
+			return nil
+		}).
+		ListOption("port", Uint16).
+		Max("port", 1024).
+		Handler(func(ctx.Context) error { return nil }).
+		Register()
+	return routeInput(t, r, input)
+}
+
+func TestRoute_Constraints_ViolationsNameOptionAndRule(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{"compress --level=0", "Option level value 0 violates min 1"},
+		{"compress --level=12", "Option level value 12 violates max 9"},
+		{"compress --ratio=0.25", "Option ratio value 0.25 violates min 0.5"},
+		{"compress --name=ab", "Option name value \"ab\" violates min length 3"},
+		{"compress --name=abcdefghi", "Option name value \"abcdefghi\" violates max length 8"},
+		{"compress --name=Abc", "Option name value \"Abc\" violates pattern ^[a-z]+$"},
+		{"compress --owner=root", "Option owner value \"root\" violates validator: root is not allowed"},
+		{"compress --port=80,8080", "Option port item 2: Option port value 8080 violates max 1024"},
+	}
+
+	for _, tc := range cases {
+		err := routeConstrainedInput(t, tc.input)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.input, tc.want, err)
+		}
+	}
+}
+
+func TestRoute_Constraints_ValidValuesPassed(t *testing.T) {
+	if err := routeConstrainedInput(t, "compress --level=9 --ratio=0.5 --name=abc --owner=admin --port=80,443"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRoute_Constraints_CompareLargeIntegersExactly(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{"store --size=9007199254740992", ""},
+		{"store --size=9007199254740993", "Option size value 9007199254740993 violates max 9007199254740992"},
+		{"store --size=18446744073709551615", "violates max 9007199254740992"},
+		{"store --offset=-1152921504606846976", ""},
+		{"store --offset=-1152921504606846977", "Option offset value -1152921504606846977 violates min -1152921504606846976"},
+		{"store --offset=2", ""},
+		{"store --offset=3", "Option offset value 3 violates max 2.5"},
+	}
+
+	for _, tc := range cases {
+		c, it := mk(tc.input, t)
+		r := NewRouter()
+
+		var gotErr error
+		r.CustomErrorHandler(func(err error, _ ctx.Context) { gotErr = err })
+		r.Endpoint("store").
+			Uint64Option("size").
+			Max("size", 1<<53).
+			Int64Option("offset").
+			Range("offset", -(1 << 60), 2.5).
+			Handler(func(ctx.Context) error { return nil }).
+			Register()
+		r.Route(*c, it)
+
+		if tc.want == "" && gotErr != nil {
+			t.Errorf("%s: unexpected error: %v", tc.input, gotErr)
+		}
+		if tc.want != "" && (gotErr == nil || !strings.Contains(gotErr.Error(), tc.want)) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.input, tc.want, gotErr)
+		}
+	}
+}
+
+func TestRange_WithNonNumericOption_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic for range on string option")
+		}
+	}()
+
+	NewRouter().Endpoint("compress").StringOption("name").Range("name", 1, 2)
+}
+
+func TestHelp_WithConstraints_RendersRules(t *testing.T) {
+	w := NewRouter().Endpoint("compress").
+		RequiredInt("level").
+		Range("level", 1, 9).
+		StringOption("name").
+		MaxLength("name", 8).
+		Pattern("name", "^[a-z]+$")
+
+	help := w.endpoint.Help()
+	for _, want := range []string{"(required, range: 1..9)", "(max length: 8, pattern: ^[a-z]+$)"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("help does not contain %q:\n%s", want, help)
+		}
+	}
+}
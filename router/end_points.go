@@ -2,6 +2,10 @@ package router
 
 import (
 	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
 
 	ctx "github.com/DilemaFixer/Cmd/context"
 )
@@ -13,6 +17,31 @@ const (
 	String
 	Int
 	Float
+	Duration
+	Timestamp
+	ByteSize
+	Path
+	File
+	Dir
+	URL
+	HostPort
+	IP
+	CIDR
+	Enum
+	List
+	Map
+	Count
+	Int8
+	Int16
+	Int32
+	Int64
+	Uint
+	Uint8
+	Uint16
+	Uint32
+	Uint64
+
+	customOptionTypeStart
 )
 
 type EndPoint struct {
@@ -20,6 +49,7 @@ type EndPoint struct {
 	handler     func(ctx.Context) error
 	options     map[string]Option
 	groups      OptionsGroups
+	rules       []optionRule
 	description string
 }
 
@@ -30,14 +60,36 @@ type OptionsGroups struct {
 
 type OptionsGroup struct {
 	Triger           string
+	Value            string
 	RequiresSolitude bool
 	Options          map[string]Option
+	Groups           map[string]OptionsGroup
 }
 
 type Option struct {
-	Name     string
-	Type     OptionType
-	Required bool
+	Name        string
+	Type        OptionType
+	Required    bool
+	Description string
+	Default     string
+	Env         string
+	PathChecks  PathCheck
+	Schemes     []string
+	Hostless    []string
+	Choices     []string
+	IgnoreCase  bool
+	Elem        OptionType
+	Separator   string
+	Short       rune
+	Negatable   bool
+	Min         *float64
+	Max         *float64
+	MinLength   int
+	MaxLength   int
+	Pattern     *regexp.Regexp
+	Validators  []func(value string) error
+	Secret      bool
+	Indirect    bool
 }
 
 func NewEndPoint(name string, handler func(ctx.Context) error) *EndPoint {
@@ -62,6 +114,7 @@ func NewOptionsGroup(trigger string, requiresSolitude bool) OptionsGroup {
 		Triger:           trigger,
 		RequiresSolitude: requiresSolitude,
 		Options:          make(map[string]Option),
+		Groups:           make(map[string]OptionsGroup),
 	}
 }
 
@@ -82,6 +135,9 @@ func (endPoint *EndPoint) Set(routePoint RoutePoint) error {
 }
 
 func (endPoint *EndPoint) ProcessAndPush(context ctx.Context, itr *RoutingIterator) (RoutePoint, error) {
+	if err := context.ExpandShortFlags(endPoint.isShortFlag); err != nil {
+		return nil, err
+	}
 	if err := endPoint.validateOptions(context); err != nil {
 		return nil, err
 	}
@@ -89,47 +145,96 @@ func (endPoint *EndPoint) ProcessAndPush(context ctx.Context, itr *RoutingIterat
 	return endPoint, endPoint.handler(context)
 }
 
+func (endPoint *EndPoint) isShortFlag(name string) bool {
+	for optionName, option := range endPoint.allOptions() {
+		if optionName == name || string(option.Short) == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (endPoint *EndPoint) validateOptions(context ctx.Context) error {
-	if err := endPoint.validateGroups(context); err != nil {
+	if err := endPoint.normalizeFlags(context); err != nil {
 		return err
 	}
 
+	applyOptionsDefaults(endPoint.options, context)
+
 	if err := endPoint.validateGlobalOptions(context); err != nil {
 		return err
 	}
 
+	if err := validateGroups(endPoint.groups.groups, context); err != nil {
+		return err
+	}
+
+	if err := endPoint.validateRules(context); err != nil {
+		return err
+	}
+
 	return nil
 }
 
-func (endPoint *EndPoint) validateGroups(context ctx.Context) error {
-	var solitudeGroupExist bool = false
-	for _, group := range endPoint.groups.groups {
-		if context.IsFlagExist(group.Triger) {
-			if solitudeGroupExist {
-				return fmt.Errorf("Routing error: Prev. group requires solitude, can't handling %s group", group.Triger)
-			}
+func validateGroups(groups map[string]OptionsGroup, context ctx.Context) error {
+	active := make([]string, 0)
+	exclusive := ""
+	for _, name := range sortedGroupNames(groups) {
+		if !groups[name].isActive(context) {
+			continue
+		}
+		active = append(active, name)
+		if groups[name].RequiresSolitude && exclusive == "" {
+			exclusive = name
+		}
+	}
 
-			solitudeGroupExist = group.RequiresSolitude
-			if err := validateGroupOptions(group.Options, context); err != nil {
-				return err
-			}
+	if exclusive != "" && len(active) > 1 {
+		other := active[0]
+		if other == exclusive {
+			other = active[1]
+		}
+		return fmt.Errorf("Routing error: Exclusive group requires solitude, can't use %s group together with %s group", other, exclusive)
+	}
+
+	for _, name := range active {
+		group := groups[name]
+		applyOptionsDefaults(group.Options, context)
+		if err := validateGroupOptions(group.Options, context); err != nil {
+			return err
+		}
+		if err := validateGroups(group.Groups, context); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
+func (group OptionsGroup) isActive(context ctx.Context) bool {
+	if !context.IsFlagExist(group.Triger) {
+		return false
+	}
+	if group.Value == "" {
+		return true
+	}
+	value, _ := context.GetValueAsString(strings.TrimPrefix(group.Triger, "--"))
+	return value == group.Value
+}
+
 func validateGroupOptions(options map[string]Option, context ctx.Context) error {
 	for _, option := range options {
-		var isExist bool = false
-		if isExist = context.IsFlagExist(option.Name); !isExist && option.Required {
-			return fmt.Errorf("Routing error: Required '%s' flag not exist", option.Name)
+		isExist := context.IsFlagExist(option.Name)
+		if !isExist && option.Required {
+			if isExist = promptSecret(option, context); !isExist {
+				return fmt.Errorf("Routing error: Required '%s' flag not exist", option.Name)
+			}
 		}
 
 		if !isExist {
-			return nil
+			continue
 		}
 
-		if err := optionTypeValidation(option, context); err != nil {
+		if err := validateOption(option, context); err != nil {
 			return err
 		}
 	}
@@ -138,36 +243,69 @@ func validateGroupOptions(options map[string]Option, context ctx.Context) error
 
 func (endPoint *EndPoint) validateGlobalOptions(context ctx.Context) error {
 	for _, option := range endPoint.options {
-		var isExist bool
-		if isExist = context.IsFlagExist(option.Name); !isExist && option.Required {
-			return fmt.Errorf("Routing error: Required '%s' flag not exist", option.Name)
+		isExist := context.IsFlagExist(option.Name)
+		if !isExist && option.Required {
+			if isExist = promptSecret(option, context); !isExist {
+				return fmt.Errorf("Routing error: Required '%s' flag not exist", option.Name)
+			}
 		}
 
-		if err := optionTypeValidation(option, context); err != nil {
+		if !isExist {
+			continue
+		}
+
+		if err := validateOption(option, context); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+func applyOptionsDefaults(options map[string]Option, context ctx.Context) {
+	for _, option := range options {
+		if context.IsFlagExist(option.Name) {
+			continue
+		}
+
+		value, exist := option.defaultValue()
+		if !exist {
+			continue
+		}
+
+		if option.Type == Bool {
+			if enabled, err := strconv.ParseBool(value); err != nil || !enabled {
+				continue
+			}
+			value = ""
+		}
+		context.SetFlag(option.Name, value)
+	}
+}
+
+func (option Option) defaultValue() (string, bool) {
+	if option.Env != "" {
+		if value, exist := os.LookupEnv(option.Env); exist && value != "" {
+			return value, true
+		}
+	}
+
+	if option.Default != "" {
+		return option.Default, true
+	}
+	return "", false
+}
+
 func optionTypeValidation(option Option, context ctx.Context) error {
 	_type := option.Type
 	switch _type {
 	case Bool:
-		if context.IsFlagHaveValue(option.Name) {
-			return fmt.Errorf("Routing error: Option %s with type Bool have value, must look like --%s", option.Name, option.Name)
-		}
+		return boolOptionValidation(option, context)
 	case String:
 		if !context.IsFlagHaveValue(option.Name) {
 			return fmt.Errorf("Routing error: Option %s with type String haven't value", option.Name)
 		}
-	case Int:
-		if !context.IsFlagHaveValue(option.Name) {
-			return fmt.Errorf("Routing error: Option %s with type Int haven't value", option.Name)
-		}
-		if _, error := context.GetValueAsInt(option.Name); error != nil {
-			return fmt.Errorf("Routing error: Option %s with type Int have error \"%s\"", option.Name, error.Error())
-		}
+	case Int, Int8, Int16, Int32, Int64, Uint, Uint8, Uint16, Uint32, Uint64:
+		return integerOptionValidation(option, context)
 	case Float:
 		if !context.IsFlagHaveValue(option.Name) {
 			return fmt.Errorf("Routing error: Option %s with type Float haven't value", option.Name)
@@ -175,9 +313,59 @@ func optionTypeValidation(option Option, context ctx.Context) error {
 		if _, error := context.GetValueAsFloat64(option.Name); error != nil {
 			return fmt.Errorf("Routing error: Option %s with type Float have error \"%s\"", option.Name, error.Error())
 		}
+	case Duration:
+		if !context.IsFlagHaveValue(option.Name) {
+			return fmt.Errorf("Routing error: Option %s with type Duration haven't value", option.Name)
+		}
+		if _, error := context.GetValueAsDuration(option.Name); error != nil {
+			return fmt.Errorf("Routing error: Option %s with type Duration have error \"%s\", accepted formats: %s", option.Name, error.Error(), ctx.DurationFormats)
+		}
+	case Timestamp:
+		if !context.IsFlagHaveValue(option.Name) {
+			return fmt.Errorf("Routing error: Option %s with type Timestamp haven't value", option.Name)
+		}
+		if _, error := context.GetValueAsTime(option.Name); error != nil {
+			return fmt.Errorf("Routing error: Option %s with type Timestamp have error \"%s\", accepted formats: %s", option.Name, error.Error(), ctx.TimestampFormats)
+		}
+	case ByteSize:
+		if !context.IsFlagHaveValue(option.Name) {
+			return fmt.Errorf("Routing error: Option %s with type ByteSize haven't value", option.Name)
+		}
+		if _, error := context.GetValueAsByteSize(option.Name); error != nil {
+			return fmt.Errorf("Routing error: Option %s with type ByteSize have error \"%s\", accepted formats: %s", option.Name, error.Error(), ctx.ByteSizeFormats)
+		}
+	case Path, File, Dir:
+		return pathOptionValidation(option, context)
+	case URL, HostPort, IP, CIDR:
+		return networkOptionValidation(option, context)
+	case Enum:
+		return enumOptionValidation(option, context)
+	case List, Map:
+		return listOptionValidation(option, context)
+	case Count:
+		return countOptionValidation(option, context)
 	default:
+		return customOptionTypeValidation(option, context)
+	}
+
+	return nil
+}
+
+func customOptionTypeValidation(option Option, context ctx.Context) error {
+	def, exist := LookupOptionType(option.Type)
+	if !exist {
 		return fmt.Errorf("Routing error: Undefine option type")
 	}
 
+	if !context.IsFlagHaveValue(option.Name) {
+		return fmt.Errorf("Routing error: Option %s with type %s haven't value", option.Name, def.Name)
+	}
+
+	value, _ := context.GetValueAsString(option.Name)
+	parsed, err := def.Parse(value)
+	if err != nil {
+		return fmt.Errorf("Routing error: Option %s with type %s have error \"%s\"", option.Name, def.Name, err.Error())
+	}
+	context.SetValue(option.Name, parsed)
 	return nil
 }
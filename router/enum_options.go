@@ -0,0 +1,37 @@
+package router
+
+import (
+	"fmt"
+	"strings"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+)
+
+func enumOptionValidation(option Option, context ctx.Context) error {
+	if !context.IsFlagHaveValue(option.Name) {
+		return fmt.Errorf("Routing error: Option %s with type Enum haven't value, choices: %s", option.Name, strings.Join(option.Choices, ", "))
+	}
+
+	value, _ := context.GetValueAsString(option.Name)
+	for _, choice := range option.Choices {
+		if choice == value {
+			return nil
+		}
+		if option.IgnoreCase && strings.EqualFold(choice, value) {
+			context.SetFlag(option.Name, choice)
+			return nil
+		}
+	}
+	return fmt.Errorf("Routing error: Option %s with type Enum have unknown value \"%s\", choices: %s", option.Name, value, strings.Join(option.Choices, ", "))
+}
+
+func completeEnum(option Option, prefix string) []string {
+	candidates := make([]string, 0, len(option.Choices))
+	for _, choice := range option.Choices {
+		if strings.HasPrefix(choice, prefix) ||
+			(option.IgnoreCase && strings.HasPrefix(strings.ToLower(choice), strings.ToLower(prefix))) {
+			candidates = append(candidates, choice)
+		}
+	}
+	return candidates
+}
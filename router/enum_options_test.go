@@ -0,0 +1,97 @@
+package router
+
+import (
+	"strings"
+	"testing"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+)
+
+func makeEnumRouter(t *testing.T, gotErr *error, got *string) *Router {
+	t.Helper()
+	r := NewRouter()
+	r.CustomErrorHandler(func(err error, _ ctx.Context) { *gotErr = err })
+	r.Endpoint("compress").
+		RequiredEnum("format", "zip", "tar.gz", "7z").
+		EnumOption("level", "fast", "Best").
+		IgnoreCase("level").
+		Handler(func(cc ctx.Context) error {
+			*got = cc.GetValueOrDefault("level", "")
+			return nil
+		}).
+		Register()
+	return r
+}
+
+func TestRoute_EnumOption_UnknownValueListsChoices(t *testing.T) {
+	var gotErr error
+	var level string
+	r := makeEnumRouter(t, &gotErr, &level)
+	c, it := mk("compress --format=rar", t)
+
+	r.Route(*c, it)
+
+	if gotErr == nil || !strings.Contains(gotErr.Error(), "unknown value \"rar\", choices: zip, tar.gz, 7z") {
+		t.Fatalf("unexpected error: %v", gotErr)
+	}
+}
+
+func TestRoute_EnumOption_CaseSensitiveByDefault(t *testing.T) {
+	var gotErr error
+	var level string
+	r := makeEnumRouter(t, &gotErr, &level)
+	c, it := mk("compress --format=ZIP", t)
+
+	r.Route(*c, it)
+
+	if gotErr == nil {
+		t.Fatalf("expected error for wrong case")
+	}
+}
+
+func TestRoute_EnumOption_IgnoreCaseNormalizesValue(t *testing.T) {
+	var gotErr error
+	var level string
+	r := makeEnumRouter(t, &gotErr, &level)
+	c, it := mk("compress --format=7z --level=BEST", t)
+
+	r.Route(*c, it)
+
+	if gotErr != nil {
+		t.Fatalf("unexpected error: %v", gotErr)
+	}
+	if level != "Best" {
+		t.Fatalf("expected canonical choice Best, got %q", level)
+	}
+}
+
+func TestEnumOption_HelpAndCompletion(t *testing.T) {
+	var gotErr error
+	var level string
+	r := makeEnumRouter(t, &gotErr, &level)
+
+	help := r.points["compress"].(*EndPoint).Help()
+	if !strings.Contains(help, "--format=<zip|tar.gz|7z>") || !strings.Contains(help, "case-insensitive") {
+		t.Fatalf("unexpected help:\n%s", help)
+	}
+
+	got := r.Complete([]string{"compress", "--format=t"})
+	if strings.Join(got, " ") != "--format=tar.gz" {
+		t.Fatalf("unexpected completion %q", got)
+	}
+	got = r.Complete([]string{"compress", "--level=b"})
+	if strings.Join(got, " ") != "--level=Best" {
+		t.Fatalf("unexpected completion %q", got)
+	}
+}
+
+func TestStructOptions_EnumTag_GeneratesEnumOption(t *testing.T) {
+	w := NewRouter().Endpoint("compress").StructOptions(struct {
+		Format string `cmd:"format" enum:"zip,tar.gz"`
+	}{})
+
+	option := w.endpoint.options["format"]
+	if option.Type != Enum || strings.Join(option.Choices, ",") != "zip,tar.gz" {
+		t.Fatalf("unexpected option %+v", option)
+	}
+}
@@ -0,0 +1,71 @@
+package router
+
+import (
+	"fmt"
+	"strconv"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+)
+
+const negationPrefix = "no-"
+
+func (endPoint *EndPoint) normalizeFlags(context ctx.Context) error {
+	stdinOwner := ""
+	options := endPoint.allOptions()
+	for _, name := range sortedOptionNames(options) {
+		option := options[name]
+		if option.Short != 0 {
+			context.RenameFlag(string(option.Short), name)
+		}
+		if option.Secret {
+			if err := resolveSecretFile(option, context); err != nil {
+				return err
+			}
+		}
+		if option.Indirect {
+			if err := resolveIndirectValues(option, context, &stdinOwner); err != nil {
+				return err
+			}
+		}
+
+		negated := negationPrefix + name
+		if !option.Negatable || !context.IsFlagExist(negated) {
+			continue
+		}
+		if context.IsFlagHaveValue(negated) {
+			return fmt.Errorf("Routing error: Option --%s can't have value, must look like --%s", negated, negated)
+		}
+		if context.IsFlagExist(name) {
+			return fmt.Errorf("Routing error: Option %s can't be used together with --%s", name, negated)
+		}
+		context.RemoveFlag(negated)
+		context.SetFlag(name, "false")
+	}
+	return nil
+}
+
+func boolOptionValidation(option Option, context ctx.Context) error {
+	if !context.IsFlagHaveValue(option.Name) {
+		return nil
+	}
+
+	value, _ := context.GetValueAsString(option.Name)
+	if _, err := strconv.ParseBool(value); err != nil {
+		return fmt.Errorf("Routing error: Option %s with type Bool have value \"%s\", must look like --%s or --%s=true|false", option.Name, value, option.Name, option.Name)
+	}
+	return nil
+}
+
+func countOptionValidation(option Option, context ctx.Context) error {
+	values, _ := context.GetValues(option.Name)
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		if n, err := strconv.Atoi(value); err != nil || n < 0 {
+			return fmt.Errorf("Routing error: Option %s with type Count have value \"%s\", must look like --%s or --%s=<count>", option.Name, value, option.Name, option.Name)
+		}
+	}
+	context.SetValue(option.Name, context.GetValueAsCount(option.Name))
+	return nil
+}
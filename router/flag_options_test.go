@@ -0,0 +1,177 @@
+package router
+
+import (
+	"strings"
+	"testing"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+)
+
+func routeFlagInput(t *testing.T, input string, build func(*EndPointWrapper) *EndPointWrapper, handler func(ctx.Context) error) error {
+	t.Helper()
+	r := NewRouter()
+	build(r.Endpoint("build")).Handler(handler).Register()
+	return routeInput(t, r, input)
+}
+
+func TestRoute_CountOption_CountsShortAndLongOccurrences(t *testing.T) {
+	for _, input := range []string{"build -vvv", "build --verbose --verbose --verbose", "build -v --verbose=2"} {
+		var count int
+		err := routeFlagInput(t, input,
+			func(w *EndPointWrapper) *EndPointWrapper {
+				return w.CountOption("verbose").Short("verbose", 'v')
+			},
+			func(cc ctx.Context) error {
+				count = cc.GetValueAsCount("verbose")
+				value, err := ctx.GetValueAs[int](cc, "verbose")
+				if err != nil || value != count {
+					t.Errorf("%s: parsed value = %v (%v), want %d", input, value, err, count)
+				}
+				return nil
+			})
+
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", input, err)
+		}
+		if count != 3 {
+			t.Errorf("%s: count = %d, want 3", input, count)
+		}
+	}
+}
+
+func TestRoute_ShortFlagsWithUnknownLetters_StayPositional(t *testing.T) {
+	cases := []struct {
+		input       string
+		subcommands string
+		flags       string
+	}{
+		{"build -word", "-word", ""},
+		{"build -v -vq", "-vq", "verbose="},
+		{"build --verbose -x=1", "-x=1", "verbose="},
+		{"build -v pattern -word", "pattern -word", "verbose="},
+		{"build -a1 -ab=1", "-a1 -ab=1", ""},
+	}
+
+	for _, tc := range cases {
+		var subcommands, flags string
+		err := routeFlagInput(t, tc.input,
+			func(w *EndPointWrapper) *EndPointWrapper {
+				return w.CountOption("verbose").Short("verbose", 'v')
+			},
+			func(cc ctx.Context) error {
+				subcommands = strings.Join(cc.GetSubcommandsAsArr(), " ")
+				flags = strings.Join(cc.GetFlagsAsArr(), " ")
+				return nil
+			})
+
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.input, err)
+		}
+		if subcommands != tc.subcommands || flags != tc.flags {
+			t.Errorf("%s: subcommands %q flags %q, want %q and %q", tc.input, subcommands, flags, tc.subcommands, tc.flags)
+		}
+	}
+}
+
+func TestRoute_GroupedKnownShortFlagsWithValue_ReturnsError(t *testing.T) {
+	err := routeFlagInput(t, "build -vv=2",
+		func(w *EndPointWrapper) *EndPointWrapper {
+			return w.CountOption("verbose").Short("verbose", 'v')
+		},
+		func(ctx.Context) error { return nil })
+
+	if err == nil || err.Error() != "Parsing err: grouped short flags -vv can't share value 2" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestRoute_CountOption_WithInvalidValue_ReturnsError(t *testing.T) {
+	err := routeFlagInput(t, "build --verbose=loud",
+		func(w *EndPointWrapper) *EndPointWrapper { return w.CountOption("verbose") },
+		func(ctx.Context) error { return nil })
+
+	if err == nil || !strings.Contains(err.Error(), "type Count have value \"loud\"") {
+		t.Fatalf("expected count error, got %v", err)
+	}
+}
+
+func TestRoute_NegatableBool_ExplicitFalseForms(t *testing.T) {
+	cases := map[string]bool{
+		"build":               true,
+		"build --cache":       true,
+		"build --no-cache":    false,
+		"build --cache=false": false,
+		"build --cache=true":  true,
+	}
+
+	for input, want := range cases {
+		var got bool
+		err := routeFlagInput(t, input,
+			func(w *EndPointWrapper) *EndPointWrapper {
+				return w.BoolOption("cache").
+					Negatable("cache").
+					updateOption("cache", func(option *Option) { option.Default = "true" })
+			},
+			func(cc ctx.Context) error {
+				got = cc.GetValueAsBool("cache")
+				return nil
+			})
+
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", input, err)
+		}
+		if got != want {
+			t.Errorf("%s: cache = %v, want %v", input, got, want)
+		}
+	}
+}
+
+func TestRoute_NegatableBool_Conflicts(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{"build --cache --no-cache", "can't be used together with --no-cache"},
+		{"build --no-cache=yes", "--no-cache can't have value"},
+		{"build --cache=maybe", "must look like --cache or --cache=true|false"},
+	}
+
+	for _, tc := range cases {
+		err := routeFlagInput(t, tc.input,
+			func(w *EndPointWrapper) *EndPointWrapper { return w.BoolOption("cache").Negatable("cache") },
+			func(ctx.Context) error { return nil })
+
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.input, tc.want, err)
+		}
+	}
+}
+
+func TestNegatable_WithNonBoolOption_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic for negatable string option")
+		}
+	}()
+
+	NewRouter().Endpoint("build").StringOption("name").Negatable("name")
+}
+
+func TestHelp_WithShortAndNegatableOptions_RendersForms(t *testing.T) {
+	w := NewRouter().Endpoint("build").
+		CountOption("verbose").
+		Short("verbose", 'v').
+		BoolOption("cache").
+		Negatable("cache")
+
+	help := w.endpoint.Help()
+	for _, want := range []string{"-v, --verbose", "(repeatable)", "--[no-]cache"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("help does not contain %q:\n%s", want, help)
+		}
+	}
+
+	if candidates := w.endpoint.completeOption("--no", ""); len(candidates) != 1 || candidates[0] != "--no-cache" {
+		t.Errorf("unexpected completion %v", candidates)
+	}
+}
@@ -0,0 +1,192 @@
+package router
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+func (endPoint *EndPoint) Help() string {
+	var builder strings.Builder
+	builder.WriteString(endPoint.name)
+	if endPoint.description != "" {
+		builder.WriteString(" - " + endPoint.description)
+	}
+	builder.WriteString("\n")
+
+	if len(endPoint.options) > 0 {
+		builder.WriteString("Options:\n")
+		writeOptionsHelp(&builder, endPoint.options, "  ")
+	}
+
+	writeGroupsHelp(&builder, endPoint.groups.groups, "")
+
+	if len(endPoint.rules) > 0 {
+		builder.WriteString("Rules:\n")
+		for _, rule := range endPoint.rules {
+			builder.WriteString("  " + rule.String() + "\n")
+		}
+	}
+	return builder.String()
+}
+
+func writeGroupsHelp(builder *strings.Builder, groups map[string]OptionsGroup, indent string) {
+	for _, name := range sortedGroupNames(groups) {
+		group := groups[name]
+		kind := "Group"
+		if group.RequiresSolitude {
+			kind = "Exclusive group"
+		}
+		trigger := strings.TrimPrefix(group.Triger, "--")
+		if group.Value != "" {
+			kind, name, trigger = "Variant", group.Value, trigger+"="+group.Value
+		}
+		fmt.Fprintf(builder, "%s%s %s (--%s):\n", indent, kind, name, trigger)
+		writeOptionsHelp(builder, group.Options, indent+"  ")
+		writeGroupsHelp(builder, group.Groups, indent+"  ")
+	}
+}
+
+func writeOptionsHelp(builder *strings.Builder, options map[string]Option, indent string) {
+	writer := tabwriter.NewWriter(builder, 0, 0, 2, ' ', 0)
+	for _, name := range sortedOptionNames(options) {
+		option := options[name]
+		fmt.Fprintf(writer, "%s%s\t%s\n", indent, optionUsage(option), optionDetails(option))
+	}
+	writer.Flush()
+}
+
+func optionUsage(option Option) string {
+	placeholder := optionPlaceholder(option.Type)
+	switch option.Type {
+	case Enum:
+		placeholder = strings.Join(option.Choices, "|")
+	case List, Map:
+		element := option
+		element.Type = option.Elem
+		element.Short = 0
+		placeholder = strings.TrimSuffix(strings.TrimPrefix(optionUsage(element), "--"+option.Name+"=<"), ">")
+		if option.Type == Map {
+			placeholder = "key=" + placeholder
+		}
+		if option.Separator != "" {
+			placeholder += option.Separator + "..."
+		}
+	}
+	usage := "--" + option.Name
+	if option.Negatable {
+		usage = "--[" + negationPrefix + "]" + option.Name
+	}
+	if placeholder != "" {
+		usage = fmt.Sprintf("%s=<%s>", usage, placeholder)
+	}
+	if option.Short != 0 {
+		usage = fmt.Sprintf("-%c, %s", option.Short, usage)
+	}
+	return usage
+}
+
+func optionPlaceholder(optionType OptionType) string {
+	switch optionType {
+	case String:
+		return "string"
+	case Int, Int8, Int16, Int32, Int64, Uint, Uint8, Uint16, Uint32, Uint64:
+		return strings.ToLower(optionType.String())
+	case Float:
+		return "float"
+	case Duration:
+		return "duration"
+	case Timestamp:
+		return "time"
+	case ByteSize:
+		return "size"
+	case Path:
+		return "path"
+	case File:
+		return "file"
+	case Dir:
+		return "dir"
+	case URL:
+		return "url"
+	case HostPort:
+		return "host:port"
+	case IP:
+		return "ip"
+	case CIDR:
+		return "cidr"
+	case Enum:
+		return "choice"
+	case Bool:
+		return ""
+	}
+
+	if def, exist := LookupOptionType(optionType); exist {
+		if def.Placeholder != "" {
+			return def.Placeholder
+		}
+		return def.Name
+	}
+	return ""
+}
+
+func optionDetails(option Option) string {
+	details := make([]string, 0)
+	if option.Required {
+		details = append(details, "required")
+	}
+	if option.Default != "" {
+		details = append(details, "default: "+option.Default)
+	}
+	if option.Env != "" {
+		details = append(details, "env: "+option.Env)
+	}
+	if isCollectionOptionType(option.Type) || option.Type == Count {
+		details = append(details, "repeatable")
+	}
+	if option.Secret {
+		details = append(details, "secret, or --"+option.Name+secretFileSuffix+"=<file>")
+	}
+	if option.Indirect {
+		details = append(details, "@file or - for stdin")
+	}
+	if option.IgnoreCase {
+		details = append(details, "case-insensitive")
+	}
+	if len(option.Schemes) > 0 {
+		details = append(details, "schemes: "+strings.Join(option.Schemes, ", "))
+	}
+	if len(option.Hostless) > 0 {
+		details = append(details, "no host: "+strings.Join(option.Hostless, ", "))
+	}
+	if option.PathChecks != 0 {
+		details = append(details, option.PathChecks.String())
+	}
+	details = append(details, optionConstraints(option)...)
+
+	if len(details) == 0 {
+		return option.Description
+	}
+	if option.Description == "" {
+		return "(" + strings.Join(details, ", ") + ")"
+	}
+	return fmt.Sprintf("%s (%s)", option.Description, strings.Join(details, ", "))
+}
+
+func sortedOptionNames(options map[string]Option) []string {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedGroupNames(groups map[string]OptionsGroup) []string {
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
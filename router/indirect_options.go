@@ -0,0 +1,56 @@
+package router
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+)
+
+const (
+	indirectFilePrefix = "@"
+	indirectStdin      = "-"
+)
+
+func resolveIndirectValues(option Option, context ctx.Context, stdinOwner *string) error {
+	values, err := context.GetValues(option.Name)
+	if err != nil {
+		return nil
+	}
+
+	for i, value := range values {
+		switch {
+		case value == indirectStdin:
+			if *stdinOwner != "" {
+				return fmt.Errorf("Routing error: Option %s can't read stdin, it is already used by --%s", option.Name, *stdinOwner)
+			}
+			*stdinOwner = option.Name
+			content, err := io.ReadAll(context.GetStdin())
+			if err != nil {
+				return fmt.Errorf("Routing error: Option %s can't read value from stdin: %s", option.Name, err.Error())
+			}
+			values[i] = trimIndirectValue(content)
+		case strings.HasPrefix(value, indirectFilePrefix+indirectFilePrefix):
+			values[i] = strings.TrimPrefix(value, indirectFilePrefix)
+		case strings.HasPrefix(value, indirectFilePrefix):
+			path, err := context.ResolvePath(strings.TrimPrefix(value, indirectFilePrefix))
+			var content []byte
+			if err == nil {
+				content, err = os.ReadFile(path)
+			}
+			if err != nil {
+				return fmt.Errorf("Routing error: Option %s can't read value from %s: %s", option.Name, value, err.Error())
+			}
+			values[i] = trimIndirectValue(content)
+		}
+	}
+	context.SetFlagValues(option.Name, values)
+	return nil
+}
+
+func trimIndirectValue(content []byte) string {
+	value := strings.TrimSuffix(string(content), "\n")
+	return strings.TrimSuffix(value, "\r")
+}
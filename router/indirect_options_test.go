@@ -0,0 +1,94 @@
+package router
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+)
+
+func routeIndirectInput(t *testing.T, workDir, stdin, input string, handler func(ctx.Context) error) error {
+	t.Helper()
+	r := NewRouter()
+	r.WorkDir(workDir)
+	r.Stdin(strings.NewReader(stdin))
+	r.Endpoint("migrate").
+		StringOption("query").
+		Indirect("query").
+		StringOption("data").
+		Indirect("data").
+		IntOption("steps").
+		Indirect("steps").
+		StringOption("note").
+		Handler(handler).
+		Register()
+	return routeInput(t, r, input)
+}
+
+func TestRoute_IndirectOptions_LoadFromFileAndStdin(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "query.sql"), []byte("SELECT 1;\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "steps.txt"), []byte("3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var query, data, note string
+	var steps int
+	err := routeIndirectInput(t, dir, "{\"rows\": 2}\n", "migrate --query=@query.sql --data=- --steps=@steps.txt --note=@literal",
+		func(cc ctx.Context) error {
+			query, _ = cc.GetValueAsString("query")
+			data, _ = cc.GetValueAsString("data")
+			steps, _ = cc.GetValueAsInt("steps")
+			note, _ = cc.GetValueAsString("note")
+			return nil
+		})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if query != "SELECT 1;" || data != "{\"rows\": 2}" || steps != 3 {
+		t.Errorf("unexpected values query=%q data=%q steps=%d", query, data, steps)
+	}
+	if note != "@literal" {
+		t.Errorf("note = %q, options without opt-in must keep @ values", note)
+	}
+}
+
+func TestRoute_IndirectOptions_EscapedAtKeepsLiteral(t *testing.T) {
+	var query string
+	err := routeIndirectInput(t, t.TempDir(), "", "migrate --query=@@admin", func(cc ctx.Context) error {
+		query, _ = cc.GetValueAsString("query")
+		return nil
+	})
+
+	if err != nil || query != "@admin" {
+		t.Fatalf("query = %q, err = %v", query, err)
+	}
+}
+
+func TestRoute_IndirectOptions_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "bad.txt"), []byte("three\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{"migrate --query=@missing.sql", "Option query can't read value from @missing.sql"},
+		{"migrate --steps=@bad.txt", "Option steps with type Int have error"},
+		{"migrate --query=- --data=-", "Option query can't read stdin, it is already used by --data"},
+	}
+
+	for _, tc := range cases {
+		err := routeIndirectInput(t, dir, "input", tc.input, func(ctx.Context) error { return nil })
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.input, tc.want, err)
+		}
+	}
+}
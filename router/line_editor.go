@@ -0,0 +1,452 @@
+package router
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/DilemaFixer/Cmd/internal/term"
+)
+
+var ErrInterrupted = errors.New("interrupted")
+
+const (
+	keyCtrlA     = 1
+	keyCtrlB     = 2
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyCtrlF     = 6
+	keyCtrlG     = 7
+	keyCtrlH     = 8
+	keyTab       = 9
+	keyLineFeed  = 10
+	keyCtrlK     = 11
+	keyCtrlL     = 12
+	keyEnter     = 13
+	keyCtrlN     = 14
+	keyCtrlP     = 16
+	keyCtrlR     = 18
+	keyCtrlU     = 21
+	keyCtrlW     = 23
+	keyCtrlY     = 25
+	keyEscape    = 27
+	keyBackspace = 127
+)
+
+const (
+	keyUp rune = unicode.MaxRune + 1 + iota
+	keyDown
+	keyRight
+	keyLeft
+	keyHome
+	keyEnd
+	keyDelete
+	keyWordLeft
+	keyWordRight
+	keyUnknown
+)
+
+type LineEditor struct {
+	input    *bufio.Reader
+	output   io.Writer
+	file     *os.File
+	complete func(line string) []string
+	history  []string
+	killed   []rune
+}
+
+func NewLineEditor(input io.Reader, output io.Writer) *LineEditor {
+	editor := &LineEditor{
+		input:   bufio.NewReader(input),
+		output:  output,
+		history: make([]string, 0),
+	}
+	if file, ok := input.(*os.File); ok && term.IsTerminal(file.Fd()) {
+		editor.file = file
+	}
+	return editor
+}
+
+func NewTerminalReader(input io.Reader, output io.Writer, complete func(line string) []string) LineReader {
+	if file, ok := input.(*os.File); ok && term.IsTerminal(file.Fd()) {
+		return NewLineEditor(input, output).Completer(complete)
+	}
+	return NewLineReader(input, output)
+}
+
+func (editor *LineEditor) Completer(complete func(line string) []string) *LineEditor {
+	editor.complete = complete
+	return editor
+}
+
+func (editor *LineEditor) AddHistory(line string) {
+	if len(editor.history) > 0 && editor.history[len(editor.history)-1] == line {
+		return
+	}
+	editor.history = append(editor.history, line)
+}
+
+func (editor *LineEditor) ReadLine(prompt string) (string, error) {
+	if editor.file != nil {
+		state, err := term.MakeRaw(editor.file.Fd())
+		if err != nil {
+			return "", err
+		}
+		defer term.Restore(editor.file.Fd(), state)
+	}
+
+	line := &editLine{editor: editor, prompt: prompt, historyIndex: len(editor.history)}
+	line.refresh()
+	for {
+		key, err := editor.readKey()
+		if err == io.EOF && len(line.buf) > 0 {
+			fmt.Fprint(editor.output, "\r\n")
+			return string(line.buf), nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if key == keyCtrlR {
+			if key, err = line.search(); err != nil {
+				return "", err
+			}
+		}
+		done, err := line.handle(key)
+		if done || err != nil {
+			return string(line.buf), err
+		}
+	}
+}
+
+func (editor *LineEditor) readKey() (rune, error) {
+	r, _, err := editor.input.ReadRune()
+	if err != nil || r != keyEscape || editor.input.Buffered() == 0 {
+		return r, err
+	}
+
+	next, _, err := editor.input.ReadRune()
+	if err != nil {
+		return keyEscape, nil
+	}
+	switch next {
+	case 'b':
+		return keyWordLeft, nil
+	case 'f':
+		return keyWordRight, nil
+	case '[', 'O':
+	default:
+		return keyUnknown, nil
+	}
+
+	sequence := make([]rune, 0, 4)
+	for {
+		r, _, err := editor.input.ReadRune()
+		if err != nil {
+			return keyUnknown, nil
+		}
+		sequence = append(sequence, r)
+		if r >= 0x40 && r <= 0x7e {
+			break
+		}
+	}
+
+	switch string(sequence) {
+	case "A":
+		return keyUp, nil
+	case "B":
+		return keyDown, nil
+	case "C":
+		return keyRight, nil
+	case "D":
+		return keyLeft, nil
+	case "H", "1~", "7~":
+		return keyHome, nil
+	case "F", "4~", "8~":
+		return keyEnd, nil
+	case "3~":
+		return keyDelete, nil
+	case "1;5D", "1;3D":
+		return keyWordLeft, nil
+	case "1;5C", "1;3C":
+		return keyWordRight, nil
+	}
+	return keyUnknown, nil
+}
+
+type editLine struct {
+	editor       *LineEditor
+	prompt       string
+	buf          []rune
+	cursor       int
+	historyIndex int
+	pending      []rune
+}
+
+func (line *editLine) handle(key rune) (bool, error) {
+	switch key {
+	case keyEnter, keyLineFeed:
+		fmt.Fprint(line.editor.output, "\r\n")
+		return true, nil
+	case keyCtrlC:
+		fmt.Fprint(line.editor.output, "^C\r\n")
+		line.buf = line.buf[:0]
+		return true, ErrInterrupted
+	case keyCtrlD:
+		if len(line.buf) == 0 {
+			fmt.Fprint(line.editor.output, "\r\n")
+			return true, io.EOF
+		}
+		line.deleteRange(line.cursor, line.cursor+1)
+	case keyDelete:
+		line.deleteRange(line.cursor, line.cursor+1)
+	case keyBackspace, keyCtrlH:
+		line.deleteRange(line.cursor-1, line.cursor)
+	case keyCtrlA, keyHome:
+		line.cursor = 0
+	case keyCtrlE, keyEnd:
+		line.cursor = len(line.buf)
+	case keyCtrlB, keyLeft:
+		line.cursor = max(line.cursor-1, 0)
+	case keyCtrlF, keyRight:
+		line.cursor = min(line.cursor+1, len(line.buf))
+	case keyWordLeft:
+		line.cursor = line.wordStart(line.cursor)
+	case keyWordRight:
+		line.cursor = line.wordEnd(line.cursor)
+	case keyCtrlK:
+		line.kill(line.cursor, len(line.buf))
+	case keyCtrlU:
+		line.kill(0, line.cursor)
+	case keyCtrlW:
+		line.kill(line.wordStart(line.cursor), line.cursor)
+	case keyCtrlY:
+		line.insert(line.editor.killed...)
+	case keyCtrlP, keyUp:
+		line.moveHistory(-1)
+	case keyCtrlN, keyDown:
+		line.moveHistory(1)
+	case keyCtrlL:
+		fmt.Fprint(line.editor.output, "\x1b[H\x1b[2J")
+	case keyTab:
+		line.completeWord()
+	default:
+		if key >= ' ' && key <= unicode.MaxRune {
+			line.insert(key)
+		}
+	}
+	line.refresh()
+	return false, nil
+}
+
+func (line *editLine) insert(runes ...rune) {
+	tail := append([]rune(nil), line.buf[line.cursor:]...)
+	line.buf = append(append(line.buf[:line.cursor], runes...), tail...)
+	line.cursor += len(runes)
+}
+
+func (line *editLine) deleteRange(from int, to int) {
+	from, to = max(from, 0), min(to, len(line.buf))
+	if from >= to {
+		return
+	}
+	line.buf = append(line.buf[:from], line.buf[to:]...)
+	line.cursor = from
+}
+
+func (line *editLine) kill(from int, to int) {
+	if from < to {
+		line.editor.killed = append([]rune(nil), line.buf[from:to]...)
+	}
+	line.deleteRange(from, to)
+}
+
+func (line *editLine) wordStart(pos int) int {
+	for pos > 0 && unicode.IsSpace(line.buf[pos-1]) {
+		pos--
+	}
+	for pos > 0 && !unicode.IsSpace(line.buf[pos-1]) {
+		pos--
+	}
+	return pos
+}
+
+func (line *editLine) wordEnd(pos int) int {
+	for pos < len(line.buf) && unicode.IsSpace(line.buf[pos]) {
+		pos++
+	}
+	for pos < len(line.buf) && !unicode.IsSpace(line.buf[pos]) {
+		pos++
+	}
+	return pos
+}
+
+func (line *editLine) moveHistory(step int) {
+	history := line.editor.history
+	index := line.historyIndex + step
+	if index < 0 || index > len(history) {
+		return
+	}
+	if line.historyIndex == len(history) {
+		line.pending = append([]rune(nil), line.buf...)
+	}
+
+	line.historyIndex = index
+	if index == len(history) {
+		line.buf = append([]rune(nil), line.pending...)
+	} else {
+		line.buf = []rune(history[index])
+	}
+	line.cursor = len(line.buf)
+}
+
+func (line *editLine) search() (rune, error) {
+	original := append([]rune(nil), line.buf...)
+	query := make([]rune, 0)
+	match := len(line.editor.history)
+
+	find := func(from int) {
+		for i := from; i >= 0; i-- {
+			if strings.Contains(line.editor.history[i], string(query)) {
+				match = i
+				line.buf = []rune(line.editor.history[i])
+				return
+			}
+		}
+	}
+
+	for {
+		fmt.Fprintf(line.editor.output, "\r(reverse-i-search)`%s': %s\x1b[K", string(query), string(line.buf))
+		key, err := line.editor.readKey()
+		if err != nil {
+			return 0, err
+		}
+
+		switch {
+		case key == keyCtrlR:
+			find(match - 1)
+		case key == keyBackspace || key == keyCtrlH:
+			if len(query) > 0 {
+				query = query[:len(query)-1]
+				find(len(line.editor.history) - 1)
+			}
+		case key == keyCtrlG:
+			line.buf = original
+			line.cursor = len(line.buf)
+			return keyUnknown, nil
+		case key >= ' ' && key <= unicode.MaxRune:
+			query = append(query, key)
+			find(min(match, len(line.editor.history)-1))
+		default:
+			line.cursor = len(line.buf)
+			line.historyIndex = len(line.editor.history)
+			line.refresh()
+			return key, nil
+		}
+	}
+}
+
+func (line *editLine) completeWord() {
+	if line.editor.complete == nil {
+		return
+	}
+	start := line.cursor
+	for start > 0 && !unicode.IsSpace(line.buf[start-1]) {
+		start--
+	}
+	word := string(line.buf[start:line.cursor])
+
+	candidates := make([]string, 0)
+	for _, candidate := range line.editor.complete(string(line.buf[:line.cursor])) {
+		if strings.HasPrefix(candidate, word) {
+			candidates = append(candidates, candidate)
+		}
+	}
+	if len(candidates) == 0 {
+		return
+	}
+	sort.Strings(candidates)
+
+	completion := commonPrefix(candidates)
+	if len(candidates) == 1 && !strings.HasSuffix(completion, "=") && !strings.HasSuffix(completion, "/") {
+		completion += " "
+	}
+	if completion != word {
+		line.deleteRange(start, line.cursor)
+		line.insert([]rune(completion)...)
+		return
+	}
+	fmt.Fprintf(line.editor.output, "\r\n%s\r\n", strings.Join(candidates, "  "))
+}
+
+func (line *editLine) refresh() {
+	visible, back := line.render(line.editor.columns())
+	fmt.Fprintf(line.editor.output, "\r%s%s\x1b[K", line.prompt, visible)
+	if back > 0 {
+		fmt.Fprintf(line.editor.output, "\x1b[%dD", back)
+	}
+}
+
+func (line *editLine) render(columns int) (string, int) {
+	buf, cursor := line.buf, line.cursor
+	if columns > 0 {
+		prompt := textWidth([]rune(line.prompt))
+		for cursor > 0 && prompt+textWidth(buf[:cursor]) >= columns {
+			buf, cursor = buf[1:], cursor-1
+		}
+		for len(buf) > cursor && prompt+textWidth(buf) >= columns {
+			buf = buf[:len(buf)-1]
+		}
+	}
+	return string(buf), textWidth(buf[cursor:])
+}
+
+func (editor *LineEditor) columns() int {
+	if editor.file == nil {
+		return 0
+	}
+	columns, _, err := term.Size(editor.file.Fd())
+	if err != nil {
+		return 0
+	}
+	return columns
+}
+
+func textWidth(runes []rune) int {
+	width := 0
+	for _, r := range runes {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+func runeWidth(r rune) int {
+	switch {
+	case unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) || unicode.IsControl(r) || r == 0x200b:
+		return 0
+	case r >= 0x1100 && r <= 0x115f, r >= 0x2e80 && r <= 0xa4cf && r != 0x303f, r >= 0xac00 && r <= 0xd7a3,
+		r >= 0xf900 && r <= 0xfaff, r >= 0xfe30 && r <= 0xfe4f, r >= 0xff00 && r <= 0xff60, r >= 0xffe0 && r <= 0xffe6,
+		r >= 0x1f300 && r <= 0x1f64f, r >= 0x1f900 && r <= 0x1f9ff, r >= 0x20000 && r <= 0x3fffd:
+		return 2
+	}
+	return 1
+}
+
+func commonPrefix(words []string) string {
+	prefix := []rune(words[0])
+	for _, word := range words[1:] {
+		runes := []rune(word)
+		i := 0
+		for i < len(prefix) && i < len(runes) && prefix[i] == runes[i] {
+			i++
+		}
+		prefix = prefix[:i]
+	}
+	return string(prefix)
+}
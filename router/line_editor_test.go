@@ -0,0 +1,189 @@
+package router
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func readLines(t *testing.T, editor *LineEditor) []string {
+	t.Helper()
+	lines := make([]string, 0)
+	for {
+		line, err := editor.ReadLine("> ")
+		if err == io.EOF {
+			return lines
+		}
+		if err == ErrInterrupted {
+			lines = append(lines, "^C")
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		lines = append(lines, line)
+	}
+}
+
+func TestLineEditor_EmacsKeysAndArrows(t *testing.T) {
+	input := strings.Join([]string{
+		"wrld\x1b[D\x1b[D\x1b[D\x1b[Do\x01hel\x05!\r",
+		"abc\x02\x02X\x06\x06Y\x7f\x1b[3~\r",
+		"one two three\x17\x17\x19\r",
+		"keep\x01\x0b\x19\x19\r",
+		"drop me\x03",
+		"a b c\x1bb\x1bbX\x1b[1;5C\x1b[FZ\n",
+		"tail",
+	}, "")
+	editor := NewLineEditor(strings.NewReader(input), io.Discard)
+
+	want := []string{"helowrld!", "aXbc", "one two ", "keepkeep", "^C", "a Xb cZ", "tail"}
+	if got := readLines(t, editor); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestLineEditor_HistoryNavigationAndSearch(t *testing.T) {
+	input := strings.Join([]string{
+		"\x1b[A\x1b[A\r",
+		"new\x10\x10\x0e\x0e\r",
+		"\x12dep\r",
+		"\x12b\x12\x12\x1b[D!\r",
+		"x\x12zzz\x07\r",
+	}, "")
+	editor := NewLineEditor(strings.NewReader(input), io.Discard)
+	for _, line := range []string{"build", "deploy --env=prod", "db status"} {
+		editor.AddHistory(line)
+	}
+
+	want := []string{"deploy --env=prod", "new", "deploy --env=prod", "buil!d", "x"}
+	if got := readLines(t, editor); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestLineEditor_TabCompletion(t *testing.T) {
+	complete := func(line string) []string {
+		switch {
+		case strings.Contains(line, "--env="):
+			return []string{"--env=dev", "--env=prod"}
+		case strings.HasSuffix(line, "--e"):
+			return []string{"--env="}
+		case strings.HasSuffix(line, "de"):
+			return []string{"deploy"}
+		}
+		return []string{"deploy", "db"}
+	}
+
+	var out strings.Builder
+	editor := NewLineEditor(strings.NewReader("de\t--e\tp\t\r\t\t\x03--env=\t\r"), &out).Completer(complete)
+
+	got := readLines(t, editor)
+	want := []string{"deploy --env=prod ", "^C", "--env="}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	for _, listing := range []string{"\r\ndb  deploy\r\n", "\r\n--env=dev  --env=prod\r\n"} {
+		if !strings.Contains(out.String(), listing) {
+			t.Errorf("expected candidates %q in output %q", listing, out.String())
+		}
+	}
+}
+
+func TestNewTerminalReader_WithoutTTY_FallsBackToPlainReader(t *testing.T) {
+	reader := NewTerminalReader(strings.NewReader("version\n"), io.Discard, nil)
+	if _, ok := reader.(*LineEditor); ok {
+		t.Fatalf("expected plain line reader for non-terminal input")
+	}
+	if line, err := reader.ReadLine("> "); err != nil || line != "version" {
+		t.Fatalf("unexpected line %q, %v", line, err)
+	}
+}
+
+func TestShell_Complete_UsesRouterTreeAndCurrentGroup(t *testing.T) {
+	calls := make([]string, 0)
+	shell := NewShell(shellRouter(&calls))
+
+	cases := map[string][]string{
+		"":                                    {"cd", "db", "exit", "help", "quit", "version"},
+		"d":                                   {"db"},
+		"db ":                                 {"migrate", "status"},
+		"db migrate up --s":                   {"--steps="},
+		"version && db m":                     {"migrate"},
+		"version 'a;b'; d":                    {"db"},
+		"db \"m":                              {"migrate"},
+		"db migrate up --note=\"x && y\" --s": {"--steps="},
+		"help db ":                            {"migrate", "status"},
+		"cd ":                                 {"db"},
+		"exit ":                               {},
+	}
+	for line, want := range cases {
+		if got := shell.Complete(line); !reflect.DeepEqual(got, want) {
+			t.Errorf("Complete(%q) = %q, want %q", line, got, want)
+		}
+	}
+
+	if err := shell.Execute("cd db"); err != nil {
+		t.Fatal(err)
+	}
+	if got := shell.Complete("m"); !reflect.DeepEqual(got, []string{"migrate"}) {
+		t.Errorf("Complete(m) inside db = %q", got)
+	}
+	if got := shell.Complete("migrate "); !reflect.DeepEqual(got, []string{"up"}) {
+		t.Errorf("Complete(migrate ) inside db = %q", got)
+	}
+	if got := shell.Complete("cd "); !reflect.DeepEqual(got, []string{"migrate"}) {
+		t.Errorf("Complete(cd ) inside db = %q", got)
+	}
+}
+
+func TestShell_FeedsHistoryToEditorAndSurvivesInterrupt(t *testing.T) {
+	calls := make([]string, 0)
+	var out strings.Builder
+	editor := NewLineEditor(strings.NewReader("ver\x03version\r\x1b[A\r"), &out)
+	shell := NewShell(shellRouter(&calls)).Name("app").Output(&out).Reader(editor)
+	editor.Completer(shell.Complete)
+
+	if err := shell.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(calls, []string{"version", "version"}) {
+		t.Fatalf("unexpected calls %v", calls)
+	}
+	if !reflect.DeepEqual(shell.History(), []string{"version"}) {
+		t.Fatalf("unexpected history %v", shell.History())
+	}
+}
+
+func TestLineEditor_LoneEscapeDoesNotSwallowNextKey(t *testing.T) {
+	input := io.MultiReader(strings.NewReader("ab\x1b"), strings.NewReader("c\r"))
+	line, err := NewLineEditor(input, io.Discard).ReadLine("> ")
+	if err != nil || line != "abc" {
+		t.Fatalf("unexpected line %q, %v", line, err)
+	}
+}
+
+func TestLineEditor_RenderFitsWidthAndWideRunes(t *testing.T) {
+	cases := []struct {
+		buf     string
+		cursor  int
+		columns int
+		visible string
+		back    int
+	}{
+		{"日本語 ok", 0, 0, "日本語 ok", 9},
+		{"0123456789abcdef", 16, 10, "9abcdef", 0},
+		{"0123456789abcdef", 2, 10, "0123456", 5},
+		{"日本語日本語", 6, 10, "日本語", 0},
+		{"日本語日本語", 6, 8, "本語", 0},
+	}
+
+	for _, tc := range cases {
+		line := &editLine{prompt: "> ", buf: []rune(tc.buf), cursor: tc.cursor}
+		visible, back := line.render(tc.columns)
+		if visible != tc.visible || back != tc.back {
+			t.Errorf("render(%q, %d, %d) = %q, %d, want %q, %d", tc.buf, tc.cursor, tc.columns, visible, back, tc.visible, tc.back)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package router
+
+import (
+	"fmt"
+	"strings"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+	prs "github.com/DilemaFixer/Cmd/parser"
+)
+
+const defaultListSeparator = ","
+
+func listOptionValidation(option Option, context ctx.Context) error {
+	values, _ := context.GetValues(option.Name)
+
+	elements := make([]string, 0, len(values))
+	for _, value := range values {
+		if value == "" {
+			return fmt.Errorf("Routing error: Option %s with type %s haven't value", option.Name, option.Type)
+		}
+
+		parts := []string{value}
+		if option.Separator != "" {
+			parts = strings.Split(value, option.Separator)
+		}
+		for _, part := range parts {
+			if part = strings.TrimSpace(part); part != "" {
+				elements = append(elements, part)
+			}
+		}
+	}
+
+	mapped := make(map[string]string)
+	for i, element := range elements {
+		value := element
+		key := ""
+		if option.Type == Map {
+			var err error
+			if key, value, err = ctx.ParseKeyValue(element); err != nil {
+				return fmt.Errorf("Routing error: Option %s item %d: %s", option.Name, i+1, err.Error())
+			}
+		}
+
+		normalized, err := validateElement(option, value, context)
+		if err != nil {
+			return fmt.Errorf("Routing error: Option %s item %d: %s", option.Name, i+1, strings.TrimPrefix(err.Error(), "Routing error: "))
+		}
+
+		if option.Type == Map {
+			mapped[key] = normalized
+			normalized = key + "=" + normalized
+		}
+		elements[i] = normalized
+	}
+
+	context.SetFlagValues(option.Name, elements)
+	if option.Type == Map {
+		context.SetValue(option.Name, mapped)
+	} else {
+		context.SetValue(option.Name, append([]string(nil), elements...))
+	}
+	return nil
+}
+
+func validateElement(option Option, value string, context ctx.Context) (string, error) {
+	element := option
+	element.Type = option.Elem
+
+	scratch := ctx.NewContext(prs.NewParserInput(context.GetCommand()))
+	if workDir, err := context.GetWorkDir(); err == nil {
+		scratch.SetWorkDir(workDir)
+	}
+	scratch.SetFlag(option.Name, value)
+
+	if err := validateOption(element, *scratch); err != nil {
+		return "", err
+	}
+	normalized, _ := scratch.GetValueAsString(option.Name)
+	return normalized, nil
+}
+
+func isCollectionOptionType(optionType OptionType) bool {
+	return optionType == List || optionType == Map
+}
@@ -0,0 +1,112 @@
+package router
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+)
+
+func TestRoute_ListOption_RepeatedAndSeparatedValuesCombined(t *testing.T) {
+	c, it := mk("sync --exclude=*.tmp --exclude=*.log;*.bak --port=80, --port=443", t)
+	r := NewRouter()
+	r.CustomErrorHandler(func(err error, _ ctx.Context) { t.Fatalf("unexpected error: %v", err) })
+
+	var exclude []string
+	var ports []int
+	r.Endpoint("sync").
+		ListOption("exclude", String).
+		Separator("exclude", ";").
+		ListOption("port", Int).
+		Handler(func(cc ctx.Context) error {
+			exclude, _ = cc.GetValueAsStringSlice("exclude")
+			ports, _ = cc.GetValueAsIntSlice("port")
+			return nil
+		}).
+		Register()
+
+	r.Route(*c, it)
+
+	if !reflect.DeepEqual(exclude, []string{"*.tmp", "*.log", "*.bak"}) {
+		t.Errorf("unexpected exclude %v", exclude)
+	}
+	if !reflect.DeepEqual(ports, []int{80, 443}) {
+		t.Errorf("unexpected ports %v", ports)
+	}
+}
+
+func TestRoute_ListOption_InvalidElementNamesItem(t *testing.T) {
+	c, it := mk("sync --port=80,http", t)
+	r := NewRouter()
+
+	var gotErr error
+	r.CustomErrorHandler(func(err error, _ ctx.Context) { gotErr = err })
+	r.Endpoint("sync").
+		RequiredList("port", Int).
+		Handler(func(ctx.Context) error { return nil }).
+		Register()
+
+	r.Route(*c, it)
+
+	if gotErr == nil || !strings.Contains(gotErr.Error(), "Option port item 2: Option port with type Int have error") {
+		t.Fatalf("unexpected error: %v", gotErr)
+	}
+}
+
+func TestRoute_MapOption_ValidatesAndNormalizesValues(t *testing.T) {
+	c, it := mk("deploy --label=env=prod --label=team=core,tier=WEB", t)
+	r := NewRouter()
+	r.CustomErrorHandler(func(err error, _ ctx.Context) { t.Fatalf("unexpected error: %v", err) })
+
+	var labels map[string]string
+	var keys []string
+	r.Endpoint("deploy").
+		MapOption("label", Enum).
+		Choices("label", "prod", "core", "web").
+		IgnoreCase("label").
+		Handler(func(cc ctx.Context) error {
+			labels, _ = cc.GetValueAsStringMap("label")
+			keys, _ = cc.GetMapKeys("label")
+			return nil
+		}).
+		Register()
+
+	r.Route(*c, it)
+
+	if !reflect.DeepEqual(labels, map[string]string{"env": "prod", "team": "core", "tier": "web"}) {
+		t.Errorf("unexpected labels %v", labels)
+	}
+	if !reflect.DeepEqual(keys, []string{"env", "team", "tier"}) {
+		t.Errorf("unexpected key order %v", keys)
+	}
+}
+
+func TestRoute_MapOption_MissingKeyRejected(t *testing.T) {
+	c, it := mk("deploy --label=prod", t)
+	r := NewRouter()
+
+	var gotErr error
+	r.CustomErrorHandler(func(err error, _ ctx.Context) { gotErr = err })
+	r.Endpoint("deploy").
+		MapOption("label", String).
+		Handler(func(ctx.Context) error { return nil }).
+		Register()
+
+	r.Route(*c, it)
+
+	if gotErr == nil || !strings.Contains(gotErr.Error(), "invalid key=value pair") {
+		t.Fatalf("unexpected error: %v", gotErr)
+	}
+}
+
+func TestHelp_ListAndMapOptions_ShowElementPlaceholder(t *testing.T) {
+	w := NewRouter().Endpoint("deploy").ListOption("port", Int).MapOption("label", String)
+
+	help := w.endpoint.Help()
+	for _, want := range []string{"--port=<int,...>", "--label=<key=string,...>", "(repeatable)"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("help does not contain %q:\n%s", want, help)
+		}
+	}
+}
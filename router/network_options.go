@@ -0,0 +1,62 @@
+package router
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+)
+
+const (
+	hostPortFormats = "host:port, 10.0.0.1:80, [::1]:80, :8080"
+	ipFormats       = "192.168.0.1, ::1, fe80::1%eth0"
+	cidrFormats     = "10.0.0.0/8, 2001:db8::/32"
+)
+
+func networkOptionValidation(option Option, context ctx.Context) error {
+	if !context.IsFlagHaveValue(option.Name) {
+		return fmt.Errorf("Routing error: Option %s with type %s haven't value", option.Name, option.Type)
+	}
+
+	var err error
+	var formats string
+	switch option.Type {
+	case URL:
+		err = validateURLOption(option, context)
+		formats = "scheme://host/path"
+		if len(option.Schemes) > 0 {
+			formats = fmt.Sprintf("%s with scheme %s", formats, strings.Join(option.Schemes, ", "))
+		}
+	case HostPort:
+		_, _, err = context.GetValueAsHostPort(option.Name)
+		formats = hostPortFormats
+	case IP:
+		_, err = context.GetValueAsIP(option.Name)
+		formats = ipFormats
+	case CIDR:
+		_, err = context.GetValueAsCIDR(option.Name)
+		formats = cidrFormats
+	}
+
+	if err != nil {
+		return fmt.Errorf("Routing error: Option %s with type %s have error \"%s\", accepted formats: %s", option.Name, option.Type, err.Error(), formats)
+	}
+	return nil
+}
+
+func validateURLOption(option Option, context ctx.Context) error {
+	value, _ := context.GetValueAsString(option.Name)
+	parsed, err := ctx.ParseURL(value, option.Hostless...)
+	if err != nil {
+		return err
+	}
+
+	if len(option.Schemes) > 0 && !slices.ContainsFunc(option.Schemes, func(scheme string) bool {
+		return strings.EqualFold(scheme, parsed.Scheme)
+	}) {
+		return fmt.Errorf("scheme %s is not allowed", parsed.Scheme)
+	}
+	context.SetValue(option.Name, parsed)
+	return nil
+}
@@ -0,0 +1,74 @@
+package router
+
+import (
+	"strings"
+	"testing"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+)
+
+func routeNetworkInput(t *testing.T, input string) error {
+	t.Helper()
+	r := NewRouter()
+	r.Endpoint("serve").
+		URLOption("upstream", "http", "https").
+		URLOption("report", "mailto").
+		AllowNoHost("report", "mailto").
+		HostPortOption("listen").
+		IPOption("bind").
+		CIDROption("allow").
+		Handler(func(ctx.Context) error { return nil }).
+		Register()
+	return routeInput(t, r, input)
+}
+
+func TestRoute_NetworkOptions_ValidValuesPassed(t *testing.T) {
+	err := routeNetworkInput(t, "serve --upstream=HTTPS://api.local/v1 --listen=:8080 --bind=::1 --allow=192.168.0.0/16")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRoute_NetworkOptions_InvalidValuesRejected(t *testing.T) {
+	cases := map[string]string{
+		"serve --upstream=ftp://files.local":  "scheme ftp is not allowed",
+		"serve --upstream=api.local":          "has no scheme",
+		"serve --upstream=localhost:8080":     "has no host",
+		"serve --upstream=https:example.com":  "has no host",
+		"serve --report=mailto:":              "has no host or path",
+		"serve --listen=localhost":            "accepted formats: host:port",
+		"serve --bind=300.1.1.1":              "type IP have error",
+		"serve --allow=10.0.0.0":              "type CIDR have error",
+		"serve --listen=localhost:http-proxy": "invalid port",
+	}
+	for input, want := range cases {
+		if err := routeNetworkInput(t, input); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: expected error containing %q, got %v", input, want, err)
+		}
+	}
+}
+
+func TestRoute_URLOption_HostlessValueIsStored(t *testing.T) {
+	c, it := mk("serve --report=mailto:ops@example.com", t)
+	r := NewRouter()
+	r.CustomErrorHandler(func(err error, _ ctx.Context) { t.Fatalf("unexpected error: %v", err) })
+
+	var opaque string
+	r.Endpoint("serve").
+		URLOption("report", "mailto").
+		AllowNoHost("report", "mailto").
+		Handler(func(cc ctx.Context) error {
+			report, err := cc.GetValueAsURL("report")
+			if err != nil {
+				return err
+			}
+			opaque = report.Opaque
+			return nil
+		}).
+		Register()
+	r.Route(*c, it)
+
+	if opaque != "ops@example.com" {
+		t.Fatalf("expected stored url, got %q", opaque)
+	}
+}
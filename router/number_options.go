@@ -0,0 +1,81 @@
+package router
+
+import (
+	"fmt"
+	"strconv"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+)
+
+func isIntegerOptionType(optionType OptionType) bool {
+	switch optionType {
+	case Int, Int8, Int16, Int32, Int64, Uint, Uint8, Uint16, Uint32, Uint64:
+		return true
+	}
+	return false
+}
+
+func integerOptionValidation(option Option, context ctx.Context) error {
+	if !context.IsFlagHaveValue(option.Name) {
+		return fmt.Errorf("Routing error: Option %s with type %s haven't value", option.Name, option.Type)
+	}
+
+	value, _ := context.GetValueAsString(option.Name)
+	parsed, err := parseIntegerOption(option.Type, value)
+	if err != nil {
+		return fmt.Errorf("Routing error: Option %s with type %s have error \"%s\", accepted formats: %s", option.Name, option.Type, err.Error(), ctx.IntegerFormats)
+	}
+	context.SetValue(option.Name, parsed)
+	return nil
+}
+
+func parseIntegerOption(optionType OptionType, value string) (any, error) {
+	switch optionType {
+	case Int, Int8, Int16, Int32, Int64:
+		parsed, err := ctx.ParseInt(value, integerOptionBits(optionType))
+		if err != nil {
+			return nil, err
+		}
+		switch optionType {
+		case Int8:
+			return int8(parsed), nil
+		case Int16:
+			return int16(parsed), nil
+		case Int32:
+			return int32(parsed), nil
+		case Int64:
+			return parsed, nil
+		}
+		return int(parsed), nil
+	default:
+		parsed, err := ctx.ParseUint(value, integerOptionBits(optionType))
+		if err != nil {
+			return nil, err
+		}
+		switch optionType {
+		case Uint8:
+			return uint8(parsed), nil
+		case Uint16:
+			return uint16(parsed), nil
+		case Uint32:
+			return uint32(parsed), nil
+		case Uint64:
+			return parsed, nil
+		}
+		return uint(parsed), nil
+	}
+}
+
+func integerOptionBits(optionType OptionType) int {
+	switch optionType {
+	case Int8, Uint8:
+		return 8
+	case Int16, Uint16:
+		return 16
+	case Int32, Uint32:
+		return 32
+	case Int64, Uint64:
+		return 64
+	}
+	return strconv.IntSize
+}
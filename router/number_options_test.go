@@ -0,0 +1,93 @@
+package router
+
+import (
+	"strings"
+	"testing"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+)
+
+func TestRoute_IntegerOptions_ValidatedAtDeclaredWidth(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{"net --port=70000", "Option port with type Uint16 have error"},
+		{"net --port=-1", "Option port with type Uint16 have error"},
+		{"net --ttl=128", "Option ttl with type Int8 have error"},
+		{"net --offset=0x80000000", "Option offset with type Int32 have error"},
+		{"net --mask=0b12", "accepted formats: " + ctx.IntegerFormats},
+	}
+
+	for _, tc := range cases {
+		c, it := mk(tc.input, t)
+		r := NewRouter()
+
+		var gotErr error
+		r.CustomErrorHandler(func(err error, _ ctx.Context) { gotErr = err })
+		r.Endpoint("net").
+			Uint16Option("port").
+			Int8Option("ttl").
+			Int32Option("offset").
+			Uint64Option("mask").
+			Handler(func(ctx.Context) error { return nil }).
+			Register()
+
+		r.Route(*c, it)
+
+		if gotErr == nil || !strings.Contains(gotErr.Error(), tc.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.input, tc.want, gotErr)
+		}
+	}
+}
+
+func TestRoute_IntegerOptions_AcceptPrefixesAndStoreTypedValues(t *testing.T) {
+	c, it := mk("net --port=0x1F90 --ttl=-0o10 --mask=0b1111_0000 --count=1_000 --id=007", t)
+	r := NewRouter()
+	r.CustomErrorHandler(func(err error, _ ctx.Context) { t.Fatalf("unexpected error: %v", err) })
+
+	called := false
+	r.Endpoint("net").
+		RequiredUint16("port").
+		Int8Option("ttl").
+		Uint8Option("mask").
+		IntOption("count").
+		Int64Option("id").
+		Handler(func(cc ctx.Context) error {
+			called = true
+			if port, _ := ctx.GetValueAs[uint16](cc, "port"); port != 8080 {
+				t.Errorf("port = %d, want 8080", port)
+			}
+			if ttl, _ := cc.GetValueAsInt8("ttl"); ttl != -8 {
+				t.Errorf("ttl = %d, want -8", ttl)
+			}
+			if mask, _ := cc.GetValueAsUint8("mask"); mask != 0xF0 {
+				t.Errorf("mask = %d, want 240", mask)
+			}
+			if count, _ := cc.GetValueAsInt("count"); count != 1000 {
+				t.Errorf("count = %d, want 1000", count)
+			}
+			if id, _ := ctx.GetValueAs[int64](cc, "id"); id != 7 {
+				t.Errorf("id = %d, want 7", id)
+			}
+			return nil
+		}).
+		Register()
+
+	r.Route(*c, it)
+
+	if !called {
+		t.Fatalf("handler was not called")
+	}
+}
+
+func TestHelp_IntegerOptions_RenderTypePlaceholder(t *testing.T) {
+	w := NewRouter().Endpoint("net").Uint16Option("port").Int64Option("offset")
+
+	help := w.endpoint.Help()
+	for _, want := range []string{"--port=<uint16>", "--offset=<int64>"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("help does not contain %q:\n%s", want, help)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package router
+
+import (
+	"fmt"
+	"strings"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+)
+
+type optionRuleKind int
+
+const (
+	ruleRequires optionRuleKind = iota
+	ruleConflicts
+	ruleRequiredIf
+	ruleAtLeastOne
+)
+
+type optionRule struct {
+	kind   optionRuleKind
+	option string
+	others []string
+}
+
+func (rule optionRule) String() string {
+	switch rule.kind {
+	case ruleRequires:
+		return fmt.Sprintf("--%s requires %s", rule.option, flagNames(rule.others))
+	case ruleConflicts:
+		return fmt.Sprintf("--%s conflicts with %s", rule.option, flagNames(rule.others))
+	case ruleRequiredIf:
+		return fmt.Sprintf("--%s is required if %s is set", rule.option, flagNames(rule.others))
+	}
+	return fmt.Sprintf("at least one of %s is required", flagNames(rule.others))
+}
+
+func (endPoint *EndPoint) validateRules(context ctx.Context) error {
+	options := endPoint.allOptions()
+	isSet := func(name string) bool {
+		if option, exist := options[name]; exist && option.Type == Bool {
+			return context.GetValueAsBool(name)
+		}
+		return context.IsFlagExist(name)
+	}
+
+	for _, rule := range endPoint.rules {
+		switch rule.kind {
+		case ruleRequires:
+			if !isSet(rule.option) {
+				continue
+			}
+			for _, other := range rule.others {
+				if !isSet(other) {
+					return fmt.Errorf("Routing error: Option %s requires --%s", rule.option, other)
+				}
+			}
+		case ruleConflicts:
+			if !isSet(rule.option) {
+				continue
+			}
+			for _, other := range rule.others {
+				if isSet(other) {
+					return fmt.Errorf("Routing error: Option %s conflicts with --%s", rule.option, other)
+				}
+			}
+		case ruleRequiredIf:
+			if isSet(rule.option) {
+				continue
+			}
+			for _, other := range rule.others {
+				if isSet(other) {
+					return fmt.Errorf("Routing error: Option %s is required if --%s is set", rule.option, other)
+				}
+			}
+		case ruleAtLeastOne:
+			found := false
+			for _, other := range rule.others {
+				found = found || isSet(other)
+			}
+			if !found {
+				return fmt.Errorf("Routing error: At least one of %s is required", flagNames(rule.others))
+			}
+		}
+	}
+	return nil
+}
+
+func flagNames(names []string) string {
+	flags := make([]string, 0, len(names))
+	for _, name := range names {
+		flags = append(flags, "--"+name)
+	}
+	return strings.Join(flags, ", ")
+}
@@ -0,0 +1,100 @@
+package router
+
+import (
+	"strings"
+	"testing"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+)
+
+func buildRuledEndpoint(r *Router) *EndPointWrapper {
+	return r.Endpoint("sync").
+		BoolOption("delete").
+		StringOption("target").
+		BoolOption("dry-run").
+		Negatable("dry-run").
+		BoolOption("force").
+		StringOption("tls-cert").
+		StringOption("tls-key").
+		StringOption("id").
+		StringOption("name").
+		Requires("delete", "target").
+		ConflictsWith("dry-run", "force").
+		RequiredIf("tls-key", "tls-cert").
+		AtLeastOneOf("id", "name")
+}
+
+func TestRoute_OptionRules_Violations(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{"sync --id=1 --delete", "Option delete requires --target"},
+		{"sync --id=1 --dry-run --force", "Option dry-run conflicts with --force"},
+		{"sync --id=1 --tls-cert=a.pem", "Option tls-key is required if --tls-cert is set"},
+		{"sync --target=/tmp", "At least one of --id, --name is required"},
+	}
+
+	for _, tc := range cases {
+		c, it := mk(tc.input, t)
+		r := NewRouter()
+
+		var gotErr error
+		r.CustomErrorHandler(func(err error, _ ctx.Context) { gotErr = err })
+		buildRuledEndpoint(r).Handler(func(ctx.Context) error { return nil }).Register()
+		r.Route(*c, it)
+
+		if gotErr == nil || !strings.Contains(gotErr.Error(), tc.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.input, tc.want, gotErr)
+		}
+	}
+}
+
+func TestRoute_OptionRules_SatisfiedInputsPassed(t *testing.T) {
+	for _, input := range []string{
+		"sync --name=docs",
+		"sync --id=1 --delete --target=/tmp",
+		"sync --id=1 --no-dry-run --force",
+		"sync --id=1 --tls-cert=a.pem --tls-key=a.key",
+	} {
+		c, it := mk(input, t)
+		r := NewRouter()
+		r.CustomErrorHandler(func(err error, _ ctx.Context) { t.Errorf("%s: unexpected error: %v", input, err) })
+		buildRuledEndpoint(r).Handler(func(ctx.Context) error { return nil }).Register()
+		r.Route(*c, it)
+	}
+}
+
+func TestHelp_WithOptionRules_RendersRules(t *testing.T) {
+	help := buildRuledEndpoint(NewRouter()).endpoint.Help()
+	for _, want := range []string{
+		"Rules:",
+		"--delete requires --target",
+		"--dry-run conflicts with --force",
+		"--tls-key is required if --tls-cert is set",
+		"at least one of --id, --name is required",
+	} {
+		if !strings.Contains(help, want) {
+			t.Errorf("help does not contain %q:\n%s", want, help)
+		}
+	}
+}
+
+func TestOptionRules_WithUnknownOption_Panics(t *testing.T) {
+	cases := map[string]func(*EndPointWrapper){
+		"requires":     func(w *EndPointWrapper) { w.Requires("delete", "tagret") },
+		"conflicts":    func(w *EndPointWrapper) { w.ConflictsWith("dryrun", "delete") },
+		"required if":  func(w *EndPointWrapper) { w.RequiredIf("target", "tls") },
+		"at least one": func(w *EndPointWrapper) { w.AtLeastOneOf("target", "uid") },
+	}
+	for name, build := range cases {
+		func() {
+			defer func() {
+				if got := recover(); got == nil || !strings.Contains(got.(string), "Error router building: endpoint \"sync\" rule uses unknown option") {
+					t.Errorf("%s: unexpected panic %v", name, got)
+				}
+			}()
+			build(NewRouter().Endpoint("sync").BoolOption("delete").StringOption("target"))
+		}()
+	}
+}
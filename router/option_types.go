@@ -0,0 +1,114 @@
+package router
+
+import (
+	"fmt"
+	"sync"
+)
+
+type OptionTypeDef struct {
+	Name        string
+	Parse       func(value string) (any, error)
+	Placeholder string
+	Complete    func(prefix string) []string
+}
+
+var (
+	optionTypesMu  sync.RWMutex
+	optionTypes    = make(map[OptionType]OptionTypeDef)
+	nextOptionType = customOptionTypeStart
+)
+
+func RegisterOptionType(def OptionTypeDef) OptionType {
+	if def.Name == "" {
+		panic("Error router building: custom option type must have a name")
+	}
+	if def.Parse == nil {
+		panic(fmt.Sprintf("Error router building: custom option type %s must have a parse function", def.Name))
+	}
+
+	optionTypesMu.Lock()
+	defer optionTypesMu.Unlock()
+
+	for _, registered := range optionTypes {
+		if registered.Name == def.Name {
+			panic(fmt.Sprintf("Error router building: option type %s already registered", def.Name))
+		}
+	}
+
+	optionType := nextOptionType
+	nextOptionType++
+	optionTypes[optionType] = def
+	return optionType
+}
+
+func LookupOptionType(optionType OptionType) (OptionTypeDef, bool) {
+	optionTypesMu.RLock()
+	defer optionTypesMu.RUnlock()
+
+	def, exist := optionTypes[optionType]
+	return def, exist
+}
+
+func (optionType OptionType) String() string {
+	switch optionType {
+	case Bool:
+		return "Bool"
+	case String:
+		return "String"
+	case Int:
+		return "Int"
+	case Float:
+		return "Float"
+	case Duration:
+		return "Duration"
+	case Timestamp:
+		return "Timestamp"
+	case ByteSize:
+		return "ByteSize"
+	case Path:
+		return "Path"
+	case File:
+		return "File"
+	case Dir:
+		return "Dir"
+	case URL:
+		return "URL"
+	case HostPort:
+		return "HostPort"
+	case IP:
+		return "IP"
+	case CIDR:
+		return "CIDR"
+	case Enum:
+		return "Enum"
+	case List:
+		return "List"
+	case Map:
+		return "Map"
+	case Count:
+		return "Count"
+	case Int8:
+		return "Int8"
+	case Int16:
+		return "Int16"
+	case Int32:
+		return "Int32"
+	case Int64:
+		return "Int64"
+	case Uint:
+		return "Uint"
+	case Uint8:
+		return "Uint8"
+	case Uint16:
+		return "Uint16"
+	case Uint32:
+		return "Uint32"
+	case Uint64:
+		return "Uint64"
+	}
+
+	if def, exist := LookupOptionType(optionType); exist {
+		return def.Name
+	}
+	return fmt.Sprintf("OptionType(%d)", int(optionType))
+}
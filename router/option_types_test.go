@@ -0,0 +1,124 @@
+package router
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+)
+
+type point struct {
+	X, Y int
+}
+
+var pointType = RegisterOptionType(OptionTypeDef{
+	Name: "Point",
+	Parse: func(value string) (any, error) {
+		var p point
+		if _, err := fmt.Sscanf(value, "%d,%d", &p.X, &p.Y); err != nil {
+			return nil, fmt.Errorf("expected x,y")
+		}
+		return p, nil
+	},
+	Placeholder: "x,y",
+	Complete: func(prefix string) []string {
+		return []string{"0,0", "10,10", "100,100"}
+	},
+})
+
+func TestRegisterOptionType_WithDuplicateName_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic for duplicate option type")
+		}
+	}()
+	RegisterOptionType(OptionTypeDef{Name: "Point", Parse: func(string) (any, error) { return nil, nil }})
+}
+
+func TestOptionTypeString_ReturnsRegisteredName(t *testing.T) {
+	if pointType.String() != "Point" {
+		t.Fatalf("expected Point, got %s", pointType.String())
+	}
+	if Float.String() != "Float" {
+		t.Fatalf("expected Float, got %s", Float.String())
+	}
+}
+
+func TestRoute_CustomOptionType_ValueParsedIntoContext(t *testing.T) {
+	c, it := mk("draw --origin=3,4", t)
+	r := NewRouter()
+	r.CustomErrorHandler(func(err error, _ ctx.Context) { t.Fatalf("unexpected error: %v", err) })
+
+	var got point
+	r.Endpoint("draw").
+		Option("origin", pointType, true).
+		Handler(func(cc ctx.Context) error {
+			var err error
+			got, err = ctx.GetValueAs[point](cc, "origin")
+			return err
+		}).
+		Register()
+
+	r.Route(*c, it)
+
+	if got != (point{X: 3, Y: 4}) {
+		t.Fatalf("expected {3 4}, got %+v", got)
+	}
+}
+
+func TestRoute_CustomOptionType_InvalidValueReturnsError(t *testing.T) {
+	c, it := mk("draw --origin=nowhere", t)
+	r := NewRouter()
+
+	var gotErr error
+	r.CustomErrorHandler(func(err error, _ ctx.Context) { gotErr = err })
+	r.Endpoint("draw").
+		Option("origin", pointType, true).
+		Handler(func(ctx.Context) error { return nil }).
+		Register()
+
+	r.Route(*c, it)
+
+	if gotErr == nil || !strings.Contains(gotErr.Error(), "with type Point have error \"expected x,y\"") {
+		t.Fatalf("unexpected error: %v", gotErr)
+	}
+}
+
+func TestHelp_CustomOptionType_UsesPlaceholder(t *testing.T) {
+	w := NewRouter().Endpoint("draw").Option("origin", pointType, false)
+	if help := w.endpoint.Help(); !strings.Contains(help, "--origin=<x,y>") {
+		t.Fatalf("expected placeholder in help:\n%s", help)
+	}
+}
+
+func TestComplete_CommandsOptionsAndValues(t *testing.T) {
+	r := NewRouter()
+	r.NewCmd("canvas").
+		Endpoint("draw").
+		Option("origin", pointType, false).
+		BoolOption("fill").
+		Handler(func(ctx.Context) error { return nil }).
+		Build().
+		Endpoint("clear").
+		Handler(func(ctx.Context) error { return nil }).
+		Build().
+		Register()
+
+	cases := []struct {
+		words []string
+		want  []string
+	}{
+		{[]string{"ca"}, []string{"canvas"}},
+		{[]string{"canvas", ""}, []string{"clear", "draw"}},
+		{[]string{"canvas", "draw", "--"}, []string{"--fill", "--origin="}},
+		{[]string{"canvas", "draw", "--fill", "--origin=1"}, []string{"--origin=10,10", "--origin=100,100"}},
+		{[]string{"unknown", ""}, []string{}},
+	}
+	for _, tc := range cases {
+		got := r.Complete(tc.words)
+		if strings.Join(got, " ") != strings.Join(tc.want, " ") {
+			t.Errorf("Complete(%q) = %q, want %q", tc.words, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+	"github.com/DilemaFixer/Cmd/internal/access"
+)
+
+type PathCheck int
+
+const (
+	PathMustExist PathCheck = 1 << iota
+	PathMustNotExist
+	PathReadable
+	PathWritable
+	PathGlob
+)
+
+func (checks PathCheck) Has(check PathCheck) bool {
+	return checks&check != 0
+}
+
+func pathOptionValidation(option Option, context ctx.Context) error {
+	if !context.IsFlagHaveValue(option.Name) {
+		return fmt.Errorf("Routing error: Option %s with type %s haven't value", option.Name, option.Type)
+	}
+
+	paths := make([]string, 0, 1)
+	if option.PathChecks.Has(PathGlob) {
+		matches, err := context.GetValueAsPaths(option.Name)
+		if err != nil {
+			return fmt.Errorf("Routing error: Option %s with type %s have error \"%s\"", option.Name, option.Type, err.Error())
+		}
+		paths = append(paths, matches...)
+		context.SetValue(option.Name, matches)
+	} else {
+		path, err := context.GetValueAsPath(option.Name)
+		if err != nil {
+			return fmt.Errorf("Routing error: Option %s with type %s have error \"%s\"", option.Name, option.Type, err.Error())
+		}
+		paths = append(paths, path)
+		context.SetValue(option.Name, path)
+	}
+
+	for _, path := range paths {
+		if err := checkPath(path, option.Type, option.PathChecks); err != nil {
+			return fmt.Errorf("Routing error: Option %s with type %s have error \"%s\"", option.Name, option.Type, err.Error())
+		}
+	}
+	return nil
+}
+
+func checkPath(path string, optionType OptionType, checks PathCheck) error {
+	info, err := os.Stat(path)
+	exist := err == nil
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	if checks.Has(PathMustExist) && !exist {
+		return fmt.Errorf("%s does not exist", path)
+	}
+	if checks.Has(PathMustNotExist) && exist {
+		return fmt.Errorf("%s already exists", path)
+	}
+
+	if exist {
+		if optionType == File && info.IsDir() {
+			return fmt.Errorf("%s is a directory, not a file", path)
+		}
+		if optionType == Dir && !info.IsDir() {
+			return fmt.Errorf("%s is not a directory", path)
+		}
+	}
+
+	if checks.Has(PathReadable) {
+		if !exist {
+			return fmt.Errorf("%s does not exist and can't be read", path)
+		}
+		if err := checkReadable(path); err != nil {
+			return fmt.Errorf("%s is not readable", path)
+		}
+	}
+
+	if checks.Has(PathWritable) {
+		target := path
+		if !exist {
+			target = filepath.Dir(path)
+		}
+		if err := access.Writable(target); err != nil {
+			return fmt.Errorf("%s is not writable", path)
+		}
+	}
+	return nil
+}
+
+func checkReadable(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func completePath(optionType OptionType, prefix string, workDir string) []string {
+	base := prefix
+	if prefix == "~" || strings.HasPrefix(prefix, "~/") || strings.HasPrefix(prefix, "~"+string(filepath.Separator)) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return []string{}
+		}
+		base = home + prefix[1:]
+	} else if !filepath.IsAbs(prefix) && workDir != "" {
+		base = workDir + string(filepath.Separator) + prefix
+	}
+
+	matches, err := filepath.Glob(base + "*")
+	if err != nil {
+		return []string{}
+	}
+
+	candidates := make([]string, 0, len(matches))
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil {
+			continue
+		}
+		candidate := prefix + strings.TrimPrefix(match, base)
+		if info.IsDir() {
+			candidates = append(candidates, candidate+string(filepath.Separator))
+			continue
+		}
+		if optionType != Dir {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates
+}
+
+func isPathOptionType(optionType OptionType) bool {
+	return optionType == Path || optionType == File || optionType == Dir
+}
+
+func joinPathChecks(name string, checks []PathCheck) PathCheck {
+	var joined PathCheck
+	for _, check := range checks {
+		joined |= check
+	}
+
+	for _, conflict := range []PathCheck{PathMustExist, PathReadable} {
+		if joined.Has(PathMustNotExist) && joined.Has(conflict) {
+			panic(fmt.Sprintf("Error router building: path option \"%s\" has contradictory checks: %s", name, PathMustNotExist|conflict))
+		}
+	}
+	return joined
+}
+
+func (checks PathCheck) String() string {
+	names := make([]string, 0)
+	for _, check := range []struct {
+		check PathCheck
+		name  string
+	}{
+		{PathMustExist, "must exist"},
+		{PathMustNotExist, "must not exist"},
+		{PathReadable, "readable"},
+		{PathWritable, "writable"},
+		{PathGlob, "glob"},
+	} {
+		if checks.Has(check.check) {
+			names = append(names, check.name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
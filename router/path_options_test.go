@@ -0,0 +1,161 @@
+package router
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+)
+
+func makePathTree(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "data"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a.log", "b.log", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, "data", name), []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func routePathInput(t *testing.T, workDir, input string, build func(*EndPointWrapper) *EndPointWrapper, handler func(ctx.Context) error) error {
+	t.Helper()
+	r := NewRouter()
+	r.WorkDir(workDir)
+	build(r.Endpoint("copy")).Handler(handler).Register()
+	return routeInput(t, r, input)
+}
+
+func TestRoute_PathOptions_ResolvedAgainstWorkDir(t *testing.T) {
+	dir := makePathTree(t)
+
+	var raw, source, target string
+	err := routePathInput(t, dir, "copy --source=data/a.log --target=out",
+		func(w *EndPointWrapper) *EndPointWrapper {
+			return w.RequiredFile("source", PathMustExist, PathReadable).
+				RequiredPath("target", PathMustNotExist, PathWritable)
+		},
+		func(cc ctx.Context) error {
+			raw, _ = cc.GetValueAsString("source")
+			source, _ = ctx.GetValueAs[string](cc, "source")
+			target, _ = cc.GetValueAsPath("target")
+			return nil
+		})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if raw != "data/a.log" {
+		t.Errorf("raw source = %q, want the flag as typed", raw)
+	}
+	if source != filepath.Join(dir, "data", "a.log") {
+		t.Errorf("source = %q, want resolved path", source)
+	}
+	if target != filepath.Join(dir, "out") {
+		t.Errorf("target = %q, want resolved path", target)
+	}
+}
+
+func TestRoute_PathOptions_ChecksFail(t *testing.T) {
+	dir := makePathTree(t)
+
+	cases := []struct {
+		input string
+		build func(*EndPointWrapper) *EndPointWrapper
+		want  string
+	}{
+		{"copy --source=missing.txt", func(w *EndPointWrapper) *EndPointWrapper {
+			return w.FileOption("source", PathMustExist)
+		}, "does not exist"},
+		{"copy --source=data", func(w *EndPointWrapper) *EndPointWrapper {
+			return w.FileOption("source")
+		}, "is a directory, not a file"},
+		{"copy --source=data/a.log", func(w *EndPointWrapper) *EndPointWrapper {
+			return w.DirOption("source")
+		}, "is not a directory"},
+		{"copy --source=data/a.log", func(w *EndPointWrapper) *EndPointWrapper {
+			return w.PathOption("source", PathMustNotExist)
+		}, "already exists"},
+		{"copy --source=data/*.csv", func(w *EndPointWrapper) *EndPointWrapper {
+			return w.FileOption("source", PathGlob)
+		}, "matched no paths"},
+	}
+
+	for _, tc := range cases {
+		err := routePathInput(t, dir, tc.input, tc.build, func(ctx.Context) error { return nil })
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.input, tc.want, err)
+		}
+	}
+}
+
+func TestRoute_PathOptions_GlobExpandsIntoList(t *testing.T) {
+	dir := makePathTree(t)
+
+	var files []string
+	err := routePathInput(t, dir, "copy --source=data/*.log",
+		func(w *EndPointWrapper) *EndPointWrapper {
+			return w.RequiredFile("source", PathGlob, PathReadable)
+		},
+		func(cc ctx.Context) error {
+			value, err := ctx.GetValueAs[[]string](cc, "source")
+			files = value
+			return err
+		})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{filepath.Join(dir, "data", "a.log"), filepath.Join(dir, "data", "b.log")}
+	if strings.Join(files, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, files)
+	}
+}
+
+func TestPathOption_WithContradictoryChecks_Panics(t *testing.T) {
+	cases := map[string]func(){
+		"path option \"out\" has contradictory checks: must exist, must not exist": func() {
+			NewRouter().Endpoint("copy").PathOption("out", PathMustExist, PathMustNotExist)
+		},
+		"path option \"in\" has contradictory checks: must not exist, readable": func() {
+			NewRouter().Endpoint("copy").Group("local", "--local").RequiredFile("in", PathReadable, PathMustNotExist)
+		},
+	}
+
+	for want, build := range cases {
+		func() {
+			defer func() {
+				if got := recover(); got != "Error router building: "+want {
+					t.Errorf("expected panic %q, got %v", want, got)
+				}
+			}()
+			build()
+		}()
+	}
+}
+
+func TestComplete_PathOption_UsesWorkDirAndHome(t *testing.T) {
+	dir := makePathTree(t)
+	t.Setenv("HOME", dir)
+
+	r := NewRouter()
+	r.WorkDir(dir)
+	r.Endpoint("copy").FileOption("source").Handler(func(ctx.Context) error { return nil }).Register()
+
+	sep := string(filepath.Separator)
+	cases := map[string][]string{
+		"--source=da":         {"--source=data" + sep},
+		"--source=data/a":     {"--source=data/a.log"},
+		"--source=~/data/not": {"--source=~/data/notes.txt"},
+	}
+	for prefix, want := range cases {
+		if got := r.Complete([]string{"copy", prefix}); strings.Join(got, " ") != strings.Join(want, " ") {
+			t.Errorf("Complete(%q) = %q, want %q", prefix, got, want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package router
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	ctx "github.com/DilemaFixer/Cmd/context"
@@ -12,6 +13,11 @@ type Router struct {
 	points       map[string]RoutePoint
 	errorHandler func(error, ctx.Context)
 	bindings     []func(*Router)
+	workDir      string
+	stdin        io.Reader
+	scriptPolicy ScriptErrorPolicy
+	scriptReport func(error)
+	scripts      []string
 }
 
 type RoutePoint interface {
@@ -25,6 +31,7 @@ func NewRouter() *Router {
 		cache:        make(map[string]RoutePoint),
 		points:       make(map[string]RoutePoint),
 		errorHandler: basicErrorHandler,
+		scriptReport: basicScriptReport,
 	}
 }
 
@@ -51,6 +58,14 @@ func (r *Router) CustomErrorHandler(errorHandler func(error, ctx.Context)) {
 	r.errorHandler = errorHandler
 }
 
+func (r *Router) WorkDir(dir string) {
+	r.workDir = dir
+}
+
+func (r *Router) Stdin(stdin io.Reader) {
+	r.stdin = stdin
+}
+
 func (r *Router) Bind(bindings ...func(*Router)) {
 	r.bindings = append(r.bindings, bindings...)
 }
@@ -62,14 +77,24 @@ func (r *Router) BindAll() {
 }
 
 func (r *Router) Route(context ctx.Context, itr *RoutingIterator) {
+	if err := r.dispatch(context, itr); err != nil {
+		r.errorHandler(err, context)
+	}
+}
+
+func (r *Router) dispatch(context ctx.Context, itr *RoutingIterator) error {
+	if r.workDir != "" {
+		context.SetWorkDir(r.workDir)
+	}
+	if r.stdin != nil {
+		context.SetStdin(r.stdin)
+	}
+
 	point, exist := r.points[itr.Get()]
 	if !exist {
-		r.errorHandler(fmt.Errorf("Routing error: try route to non-existent point %s", itr.Get()), context)
-		return
+		return fmt.Errorf("Routing error: try route to non-existent point %s", itr.Get())
 	}
 	itr.Next()
-	point, err := point.ProcessAndPush(context, itr)
-	if err != nil {
-		r.errorHandler(err, context)
-	}
+	_, err := point.ProcessAndPush(context, itr)
+	return maskSecrets(context, err)
 }
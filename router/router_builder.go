@@ -2,6 +2,8 @@ package router
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
 
 	ctx "github.com/DilemaFixer/Cmd/context"
 )
@@ -21,6 +23,7 @@ type EndPointWrapper struct {
 type EndPointGroupWrapper struct {
 	endpointWrapper *EndPointWrapper
 	groupName       string
+	parent          *EndPointGroupWrapper
 }
 
 func (r *Router) NewCmd(name string) *CmdWrapper {
@@ -101,6 +104,78 @@ func (w *EndPointWrapper) RequiredInt(name string) *EndPointWrapper {
 	return w.Option(name, Int, true)
 }
 
+func (w *EndPointWrapper) Int8Option(name string) *EndPointWrapper {
+	return w.Option(name, Int8, false)
+}
+
+func (w *EndPointWrapper) RequiredInt8(name string) *EndPointWrapper {
+	return w.Option(name, Int8, true)
+}
+
+func (w *EndPointWrapper) Int16Option(name string) *EndPointWrapper {
+	return w.Option(name, Int16, false)
+}
+
+func (w *EndPointWrapper) RequiredInt16(name string) *EndPointWrapper {
+	return w.Option(name, Int16, true)
+}
+
+func (w *EndPointWrapper) Int32Option(name string) *EndPointWrapper {
+	return w.Option(name, Int32, false)
+}
+
+func (w *EndPointWrapper) RequiredInt32(name string) *EndPointWrapper {
+	return w.Option(name, Int32, true)
+}
+
+func (w *EndPointWrapper) Int64Option(name string) *EndPointWrapper {
+	return w.Option(name, Int64, false)
+}
+
+func (w *EndPointWrapper) RequiredInt64(name string) *EndPointWrapper {
+	return w.Option(name, Int64, true)
+}
+
+func (w *EndPointWrapper) UintOption(name string) *EndPointWrapper {
+	return w.Option(name, Uint, false)
+}
+
+func (w *EndPointWrapper) RequiredUint(name string) *EndPointWrapper {
+	return w.Option(name, Uint, true)
+}
+
+func (w *EndPointWrapper) Uint8Option(name string) *EndPointWrapper {
+	return w.Option(name, Uint8, false)
+}
+
+func (w *EndPointWrapper) RequiredUint8(name string) *EndPointWrapper {
+	return w.Option(name, Uint8, true)
+}
+
+func (w *EndPointWrapper) Uint16Option(name string) *EndPointWrapper {
+	return w.Option(name, Uint16, false)
+}
+
+func (w *EndPointWrapper) RequiredUint16(name string) *EndPointWrapper {
+	return w.Option(name, Uint16, true)
+}
+
+func (w *EndPointWrapper) Uint32Option(name string) *EndPointWrapper {
+	return w.Option(name, Uint32, false)
+}
+
+func (w *EndPointWrapper) RequiredUint32(name string) *EndPointWrapper {
+	return w.Option(name, Uint32, true)
+}
+
+func (w *EndPointWrapper) Uint64Option(name string) *EndPointWrapper {
+	return w.Option(name, Uint64, false)
+}
+
+func (w *EndPointWrapper) RequiredUint64(name string) *EndPointWrapper {
+	return w.Option(name, Uint64, true)
+}
+
 func (w *EndPointWrapper) BoolOption(name string) *EndPointWrapper {
 	return w.Option(name, Bool, false)
 }
@@ -117,6 +192,302 @@ func (w *EndPointWrapper) RequiredFloat(name string) *EndPointWrapper {
 	return w.Option(name, Float, true)
 }
 
+func (w *EndPointWrapper) DurationOption(name string) *EndPointWrapper {
+	return w.Option(name, Duration, false)
+}
+
+func (w *EndPointWrapper) RequiredDuration(name string) *EndPointWrapper {
+	return w.Option(name, Duration, true)
+}
+
+func (w *EndPointWrapper) TimestampOption(name string) *EndPointWrapper {
+	return w.Option(name, Timestamp, false)
+}
+
+func (w *EndPointWrapper) RequiredTimestamp(name string) *EndPointWrapper {
+	return w.Option(name, Timestamp, true)
+}
+
+func (w *EndPointWrapper) ByteSizeOption(name string) *EndPointWrapper {
+	return w.Option(name, ByteSize, false)
+}
+
+func (w *EndPointWrapper) RequiredByteSize(name string) *EndPointWrapper {
+	return w.Option(name, ByteSize, true)
+}
+
+func (w *EndPointWrapper) PathOption(name string, checks ...PathCheck) *EndPointWrapper {
+	return w.pathOption(name, Path, false, checks)
+}
+
+func (w *EndPointWrapper) RequiredPath(name string, checks ...PathCheck) *EndPointWrapper {
+	return w.pathOption(name, Path, true, checks)
+}
+
+func (w *EndPointWrapper) FileOption(name string, checks ...PathCheck) *EndPointWrapper {
+	return w.pathOption(name, File, false, checks)
+}
+
+func (w *EndPointWrapper) RequiredFile(name string, checks ...PathCheck) *EndPointWrapper {
+	return w.pathOption(name, File, true, checks)
+}
+
+func (w *EndPointWrapper) DirOption(name string, checks ...PathCheck) *EndPointWrapper {
+	return w.pathOption(name, Dir, false, checks)
+}
+
+func (w *EndPointWrapper) RequiredDir(name string, checks ...PathCheck) *EndPointWrapper {
+	return w.pathOption(name, Dir, true, checks)
+}
+
+func (w *EndPointWrapper) pathOption(name string, optType OptionType, required bool, checks []PathCheck) *EndPointWrapper {
+	option := NewOption(name, optType, required)
+	option.PathChecks = joinPathChecks(name, checks)
+	w.endpoint.options[name] = option
+	return w
+}
+
+func (w *EndPointWrapper) URLOption(name string, schemes ...string) *EndPointWrapper {
+	return w.urlOption(name, false, schemes)
+}
+
+func (w *EndPointWrapper) RequiredURL(name string, schemes ...string) *EndPointWrapper {
+	return w.urlOption(name, true, schemes)
+}
+
+func (w *EndPointWrapper) urlOption(name string, required bool, schemes []string) *EndPointWrapper {
+	option := NewOption(name, URL, required)
+	option.Schemes = schemes
+	w.endpoint.options[name] = option
+	return w
+}
+
+func (w *EndPointWrapper) AllowNoHost(name string, schemes ...string) *EndPointWrapper {
+	return w.updateOption(name, func(option *Option) {
+		if option.Type != URL {
+			panic(fmt.Sprintf("Error router building: option \"%s\" with type %s isn't a URL", name, option.Type))
+		}
+		option.Hostless = schemes
+	})
+}
+
+func (w *EndPointWrapper) HostPortOption(name string) *EndPointWrapper {
+	return w.Option(name, HostPort, false)
+}
+
+func (w *EndPointWrapper) RequiredHostPort(name string) *EndPointWrapper {
+	return w.Option(name, HostPort, true)
+}
+
+func (w *EndPointWrapper) IPOption(name string) *EndPointWrapper {
+	return w.Option(name, IP, false)
+}
+
+func (w *EndPointWrapper) RequiredIP(name string) *EndPointWrapper {
+	return w.Option(name, IP, true)
+}
+
+func (w *EndPointWrapper) CIDROption(name string) *EndPointWrapper {
+	return w.Option(name, CIDR, false)
+}
+
+func (w *EndPointWrapper) RequiredCIDR(name string) *EndPointWrapper {
+	return w.Option(name, CIDR, true)
+}
+
+func (w *EndPointWrapper) EnumOption(name string, choices ...string) *EndPointWrapper {
+	return w.enumOption(name, false, choices)
+}
+
+func (w *EndPointWrapper) RequiredEnum(name string, choices ...string) *EndPointWrapper {
+	return w.enumOption(name, true, choices)
+}
+
+func (w *EndPointWrapper) enumOption(name string, required bool, choices []string) *EndPointWrapper {
+	if len(choices) == 0 {
+		panic(fmt.Sprintf("Error router building: enum option \"%s\" must have choices", name))
+	}
+	option := NewOption(name, Enum, required)
+	option.Choices = choices
+	w.endpoint.options[name] = option
+	return w
+}
+
+func (w *EndPointWrapper) ListOption(name string, elem OptionType) *EndPointWrapper {
+	return w.collectionOption(name, List, elem, false)
+}
+
+func (w *EndPointWrapper) RequiredList(name string, elem OptionType) *EndPointWrapper {
+	return w.collectionOption(name, List, elem, true)
+}
+
+func (w *EndPointWrapper) MapOption(name string, elem OptionType) *EndPointWrapper {
+	return w.collectionOption(name, Map, elem, false)
+}
+
+func (w *EndPointWrapper) RequiredMap(name string, elem OptionType) *EndPointWrapper {
+	return w.collectionOption(name, Map, elem, true)
+}
+
+func (w *EndPointWrapper) collectionOption(name string, optType, elem OptionType, required bool) *EndPointWrapper {
+	w.endpoint.options[name] = newCollectionOption(name, optType, elem, required)
+	return w
+}
+
+func (w *EndPointWrapper) CountOption(name string) *EndPointWrapper {
+	return w.Option(name, Count, false)
+}
+
+func (w *EndPointWrapper) Short(name string, short rune) *EndPointWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.Short = short
+	})
+}
+
+func (w *EndPointWrapper) Negatable(name string) *EndPointWrapper {
+	return w.updateOption(name, func(option *Option) {
+		if option.Type != Bool {
+			panic(fmt.Sprintf("Error router building: option \"%s\" with type %s can't be negatable", name, option.Type))
+		}
+		option.Negatable = true
+	})
+}
+
+func (w *EndPointWrapper) Range(name string, min, max float64) *EndPointWrapper {
+	return w.Min(name, min).Max(name, max)
+}
+
+func (w *EndPointWrapper) Min(name string, min float64) *EndPointWrapper {
+	return w.updateOption(name, func(option *Option) {
+		requireNumericOption(*option)
+		option.Min = &min
+	})
+}
+
+func (w *EndPointWrapper) Max(name string, max float64) *EndPointWrapper {
+	return w.updateOption(name, func(option *Option) {
+		requireNumericOption(*option)
+		option.Max = &max
+	})
+}
+
+func (w *EndPointWrapper) MinLength(name string, length int) *EndPointWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.MinLength = length
+	})
+}
+
+func (w *EndPointWrapper) MaxLength(name string, length int) *EndPointWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.MaxLength = length
+	})
+}
+
+func (w *EndPointWrapper) Pattern(name, expr string) *EndPointWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.Pattern = regexp.MustCompile(expr)
+	})
+}
+
+func (w *EndPointWrapper) Validate(name string, validator func(value string) error) *EndPointWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.Validators = append(option.Validators, validator)
+	})
+}
+
+func (w *EndPointWrapper) SecretOption(name string) *EndPointWrapper {
+	return w.Option(name, String, false).Secret(name)
+}
+
+func (w *EndPointWrapper) RequiredSecret(name string) *EndPointWrapper {
+	return w.Option(name, String, true).Secret(name)
+}
+
+func (w *EndPointWrapper) Secret(name string) *EndPointWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.Secret = true
+	})
+}
+
+func (w *EndPointWrapper) Indirect(name string) *EndPointWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.Indirect = true
+	})
+}
+
+func (w *EndPointWrapper) Default(name, value string) *EndPointWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.Default = value
+	})
+}
+
+func (w *EndPointWrapper) Env(name, variable string) *EndPointWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.Env = variable
+	})
+}
+
+func (w *EndPointWrapper) Separator(name, separator string) *EndPointWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.Separator = separator
+	})
+}
+
+func (w *EndPointWrapper) Choices(name string, choices ...string) *EndPointWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.Choices = choices
+	})
+}
+
+func (w *EndPointWrapper) IgnoreCase(name string) *EndPointWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.IgnoreCase = true
+	})
+}
+
+func (w *EndPointWrapper) updateOption(name string, update func(*Option)) *EndPointWrapper {
+	option, exist := w.endpoint.options[name]
+	if !exist {
+		panic(fmt.Sprintf("Error router building: endpoint \"%s\" haven't option \"%s\"", w.endpoint.name, name))
+	}
+	update(&option)
+	w.endpoint.options[name] = option
+	return w
+}
+
+func (w *EndPointWrapper) Requires(name string, others ...string) *EndPointWrapper {
+	return w.rule(ruleRequires, name, others)
+}
+
+func (w *EndPointWrapper) ConflictsWith(name string, others ...string) *EndPointWrapper {
+	return w.rule(ruleConflicts, name, others)
+}
+
+func (w *EndPointWrapper) RequiredIf(name, trigger string) *EndPointWrapper {
+	return w.rule(ruleRequiredIf, name, []string{trigger})
+}
+
+func (w *EndPointWrapper) AtLeastOneOf(names ...string) *EndPointWrapper {
+	if len(names) < 2 {
+		panic(fmt.Sprintf("Error router building: endpoint \"%s\" at least one of rule needs two or more options", w.endpoint.name))
+	}
+	return w.rule(ruleAtLeastOne, "", names)
+}
+
+func (w *EndPointWrapper) rule(kind optionRuleKind, name string, others []string) *EndPointWrapper {
+	if len(others) == 0 {
+		panic(fmt.Sprintf("Error router building: endpoint \"%s\" rule for option \"%s\" haven't related options", w.endpoint.name, name))
+	}
+	options := w.endpoint.allOptions()
+	for _, option := range append([]string{name}, others...) {
+		if _, exist := options[option]; option != "" && !exist {
+			panic(fmt.Sprintf("Error router building: endpoint \"%s\" rule uses unknown option \"%s\"", w.endpoint.name, option))
+		}
+	}
+	w.endpoint.rules = append(w.endpoint.rules, optionRule{kind: kind, option: name, others: others})
+	return w
+}
+
 func (w *EndPointWrapper) Group(name, trigger string) *EndPointGroupWrapper {
 	group := NewOptionsGroup(trigger, false)
 	w.endpoint.groups.groups[name] = group
@@ -137,6 +508,15 @@ func (w *EndPointWrapper) ExclusiveGroup(name, trigger string) *EndPointGroupWra
 	}
 }
 
+func (w *EndPointWrapper) Variant(discriminator, value string) *EndPointGroupWrapper {
+	name := addVariantGroup(w.endpoint.name, w.endpoint.options, w.endpoint.groups.groups, discriminator, value)
+
+	return &EndPointGroupWrapper{
+		endpointWrapper: w,
+		groupName:       name,
+	}
+}
+
 func (w *EndPointWrapper) SetGroupsCanBeIgnored(canBeIgnored bool) *EndPointWrapper {
 	w.endpoint.groups.CanBeIgnored = canBeIgnored
 	return w
@@ -163,9 +543,7 @@ func (w *EndPointWrapper) Register() {
 }
 
 func (w *EndPointGroupWrapper) GroupOption(name string, optType OptionType, required bool) *EndPointGroupWrapper {
-	group := w.endpointWrapper.endpoint.groups.groups[w.groupName]
-	group.Options[name] = NewOption(name, optType, required)
-	w.endpointWrapper.endpoint.groups.groups[w.groupName] = group
+	w.group().Options[name] = NewOption(name, optType, required)
 	return w
 }
 
@@ -185,6 +563,78 @@ func (w *EndPointGroupWrapper) RequiredInt(name string) *EndPointGroupWrapper {
 	return w.GroupOption(name, Int, true)
 }
 
+func (w *EndPointGroupWrapper) Int8Option(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Int8, false)
+}
+
+func (w *EndPointGroupWrapper) RequiredInt8(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Int8, true)
+}
+
+func (w *EndPointGroupWrapper) Int16Option(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Int16, false)
+}
+
+func (w *EndPointGroupWrapper) RequiredInt16(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Int16, true)
+}
+
+func (w *EndPointGroupWrapper) Int32Option(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Int32, false)
+}
+
+func (w *EndPointGroupWrapper) RequiredInt32(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Int32, true)
+}
+
+func (w *EndPointGroupWrapper) Int64Option(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Int64, false)
+}
+
+func (w *EndPointGroupWrapper) RequiredInt64(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Int64, true)
+}
+
+func (w *EndPointGroupWrapper) UintOption(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Uint, false)
+}
+
+func (w *EndPointGroupWrapper) RequiredUint(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Uint, true)
+}
+
+func (w *EndPointGroupWrapper) Uint8Option(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Uint8, false)
+}
+
+func (w *EndPointGroupWrapper) RequiredUint8(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Uint8, true)
+}
+
+func (w *EndPointGroupWrapper) Uint16Option(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Uint16, false)
+}
+
+func (w *EndPointGroupWrapper) RequiredUint16(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Uint16, true)
+}
+
+func (w *EndPointGroupWrapper) Uint32Option(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Uint32, false)
+}
+
+func (w *EndPointGroupWrapper) RequiredUint32(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Uint32, true)
+}
+
+func (w *EndPointGroupWrapper) Uint64Option(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Uint64, false)
+}
+
+func (w *EndPointGroupWrapper) RequiredUint64(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Uint64, true)
+}
+
 func (w *EndPointGroupWrapper) BoolOption(name string) *EndPointGroupWrapper {
 	return w.GroupOption(name, Bool, false)
 }
@@ -201,6 +651,364 @@ func (w *EndPointGroupWrapper) RequiredFloat(name string) *EndPointGroupWrapper
 	return w.GroupOption(name, Float, true)
 }
 
+func (w *EndPointGroupWrapper) DurationOption(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Duration, false)
+}
+
+func (w *EndPointGroupWrapper) RequiredDuration(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Duration, true)
+}
+
+func (w *EndPointGroupWrapper) TimestampOption(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Timestamp, false)
+}
+
+func (w *EndPointGroupWrapper) RequiredTimestamp(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Timestamp, true)
+}
+
+func (w *EndPointGroupWrapper) ByteSizeOption(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, ByteSize, false)
+}
+
+func (w *EndPointGroupWrapper) RequiredByteSize(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, ByteSize, true)
+}
+
+func (w *EndPointGroupWrapper) PathOption(name string, checks ...PathCheck) *EndPointGroupWrapper {
+	return w.pathOption(name, Path, false, checks)
+}
+
+func (w *EndPointGroupWrapper) RequiredPath(name string, checks ...PathCheck) *EndPointGroupWrapper {
+	return w.pathOption(name, Path, true, checks)
+}
+
+func (w *EndPointGroupWrapper) FileOption(name string, checks ...PathCheck) *EndPointGroupWrapper {
+	return w.pathOption(name, File, false, checks)
+}
+
+func (w *EndPointGroupWrapper) RequiredFile(name string, checks ...PathCheck) *EndPointGroupWrapper {
+	return w.pathOption(name, File, true, checks)
+}
+
+func (w *EndPointGroupWrapper) DirOption(name string, checks ...PathCheck) *EndPointGroupWrapper {
+	return w.pathOption(name, Dir, false, checks)
+}
+
+func (w *EndPointGroupWrapper) RequiredDir(name string, checks ...PathCheck) *EndPointGroupWrapper {
+	return w.pathOption(name, Dir, true, checks)
+}
+
+func (w *EndPointGroupWrapper) pathOption(name string, optType OptionType, required bool, checks []PathCheck) *EndPointGroupWrapper {
+	option := NewOption(name, optType, required)
+	option.PathChecks = joinPathChecks(name, checks)
+	group := w.group()
+	group.Options[name] = option
+	return w
+}
+
+func (w *EndPointGroupWrapper) URLOption(name string, schemes ...string) *EndPointGroupWrapper {
+	return w.urlOption(name, false, schemes)
+}
+
+func (w *EndPointGroupWrapper) RequiredURL(name string, schemes ...string) *EndPointGroupWrapper {
+	return w.urlOption(name, true, schemes)
+}
+
+func (w *EndPointGroupWrapper) urlOption(name string, required bool, schemes []string) *EndPointGroupWrapper {
+	option := NewOption(name, URL, required)
+	option.Schemes = schemes
+	group := w.group()
+	group.Options[name] = option
+	return w
+}
+
+func (w *EndPointGroupWrapper) AllowNoHost(name string, schemes ...string) *EndPointGroupWrapper {
+	return w.updateOption(name, func(option *Option) {
+		if option.Type != URL {
+			panic(fmt.Sprintf("Error router building: option \"%s\" with type %s isn't a URL", name, option.Type))
+		}
+		option.Hostless = schemes
+	})
+}
+
+func (w *EndPointGroupWrapper) HostPortOption(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, HostPort, false)
+}
+
+func (w *EndPointGroupWrapper) RequiredHostPort(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, HostPort, true)
+}
+
+func (w *EndPointGroupWrapper) IPOption(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, IP, false)
+}
+
+func (w *EndPointGroupWrapper) RequiredIP(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, IP, true)
+}
+
+func (w *EndPointGroupWrapper) CIDROption(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, CIDR, false)
+}
+
+func (w *EndPointGroupWrapper) RequiredCIDR(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, CIDR, true)
+}
+
+func (w *EndPointGroupWrapper) EnumOption(name string, choices ...string) *EndPointGroupWrapper {
+	return w.enumOption(name, false, choices)
+}
+
+func (w *EndPointGroupWrapper) RequiredEnum(name string, choices ...string) *EndPointGroupWrapper {
+	return w.enumOption(name, true, choices)
+}
+
+func (w *EndPointGroupWrapper) enumOption(name string, required bool, choices []string) *EndPointGroupWrapper {
+	if len(choices) == 0 {
+		panic(fmt.Sprintf("Error router building: enum option \"%s\" must have choices", name))
+	}
+	option := NewOption(name, Enum, required)
+	option.Choices = choices
+	group := w.group()
+	group.Options[name] = option
+	return w
+}
+
+func (w *EndPointGroupWrapper) ListOption(name string, elem OptionType) *EndPointGroupWrapper {
+	return w.collectionOption(name, List, elem, false)
+}
+
+func (w *EndPointGroupWrapper) RequiredList(name string, elem OptionType) *EndPointGroupWrapper {
+	return w.collectionOption(name, List, elem, true)
+}
+
+func (w *EndPointGroupWrapper) MapOption(name string, elem OptionType) *EndPointGroupWrapper {
+	return w.collectionOption(name, Map, elem, false)
+}
+
+func (w *EndPointGroupWrapper) RequiredMap(name string, elem OptionType) *EndPointGroupWrapper {
+	return w.collectionOption(name, Map, elem, true)
+}
+
+func (w *EndPointGroupWrapper) collectionOption(name string, optType, elem OptionType, required bool) *EndPointGroupWrapper {
+	group := w.group()
+	group.Options[name] = newCollectionOption(name, optType, elem, required)
+	return w
+}
+
+func (w *EndPointGroupWrapper) CountOption(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, Count, false)
+}
+
+func (w *EndPointGroupWrapper) Short(name string, short rune) *EndPointGroupWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.Short = short
+	})
+}
+
+func (w *EndPointGroupWrapper) Negatable(name string) *EndPointGroupWrapper {
+	return w.updateOption(name, func(option *Option) {
+		if option.Type != Bool {
+			panic(fmt.Sprintf("Error router building: option \"%s\" with type %s can't be negatable", name, option.Type))
+		}
+		option.Negatable = true
+	})
+}
+
+func (w *EndPointGroupWrapper) Range(name string, min, max float64) *EndPointGroupWrapper {
+	return w.Min(name, min).Max(name, max)
+}
+
+func (w *EndPointGroupWrapper) Min(name string, min float64) *EndPointGroupWrapper {
+	return w.updateOption(name, func(option *Option) {
+		requireNumericOption(*option)
+		option.Min = &min
+	})
+}
+
+func (w *EndPointGroupWrapper) Max(name string, max float64) *EndPointGroupWrapper {
+	return w.updateOption(name, func(option *Option) {
+		requireNumericOption(*option)
+		option.Max = &max
+	})
+}
+
+func (w *EndPointGroupWrapper) MinLength(name string, length int) *EndPointGroupWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.MinLength = length
+	})
+}
+
+func (w *EndPointGroupWrapper) MaxLength(name string, length int) *EndPointGroupWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.MaxLength = length
+	})
+}
+
+func (w *EndPointGroupWrapper) Pattern(name, expr string) *EndPointGroupWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.Pattern = regexp.MustCompile(expr)
+	})
+}
+
+func (w *EndPointGroupWrapper) Validate(name string, validator func(value string) error) *EndPointGroupWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.Validators = append(option.Validators, validator)
+	})
+}
+
+func (w *EndPointGroupWrapper) SecretOption(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, String, false).Secret(name)
+}
+
+func (w *EndPointGroupWrapper) RequiredSecret(name string) *EndPointGroupWrapper {
+	return w.GroupOption(name, String, true).Secret(name)
+}
+
+func (w *EndPointGroupWrapper) Secret(name string) *EndPointGroupWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.Secret = true
+	})
+}
+
+func (w *EndPointGroupWrapper) Indirect(name string) *EndPointGroupWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.Indirect = true
+	})
+}
+
+func (w *EndPointGroupWrapper) Default(name, value string) *EndPointGroupWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.Default = value
+	})
+}
+
+func (w *EndPointGroupWrapper) Env(name, variable string) *EndPointGroupWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.Env = variable
+	})
+}
+
+func (w *EndPointGroupWrapper) Separator(name, separator string) *EndPointGroupWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.Separator = separator
+	})
+}
+
+func (w *EndPointGroupWrapper) Choices(name string, choices ...string) *EndPointGroupWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.Choices = choices
+	})
+}
+
+func (w *EndPointGroupWrapper) IgnoreCase(name string) *EndPointGroupWrapper {
+	return w.updateOption(name, func(option *Option) {
+		option.IgnoreCase = true
+	})
+}
+
+func (w *EndPointGroupWrapper) updateOption(name string, update func(*Option)) *EndPointGroupWrapper {
+	group := w.group()
+	option, exist := group.Options[name]
+	if !exist {
+		panic(fmt.Sprintf("Error router building: group \"%s\" haven't option \"%s\"", w.groupName, name))
+	}
+	update(&option)
+	group.Options[name] = option
+	return w
+}
+
+func (w *EndPointGroupWrapper) SubGroup(name, trigger string) *EndPointGroupWrapper {
+	return w.subGroup(name, NewOptionsGroup(trigger, false))
+}
+
+func (w *EndPointGroupWrapper) ExclusiveSubGroup(name, trigger string) *EndPointGroupWrapper {
+	return w.subGroup(name, NewOptionsGroup(trigger, true))
+}
+
+func (w *EndPointGroupWrapper) SubVariant(discriminator, value string) *EndPointGroupWrapper {
+	group := w.group()
+	name := addVariantGroup(w.groupName, group.Options, group.Groups, discriminator, value)
+
+	return &EndPointGroupWrapper{
+		endpointWrapper: w.endpointWrapper,
+		groupName:       name,
+		parent:          w,
+	}
+}
+
+func (w *EndPointGroupWrapper) subGroup(name string, group OptionsGroup) *EndPointGroupWrapper {
+	w.group().Groups[name] = group
+
+	return &EndPointGroupWrapper{
+		endpointWrapper: w.endpointWrapper,
+		groupName:       name,
+		parent:          w,
+	}
+}
+
+func (w *EndPointGroupWrapper) EndSubGroup() *EndPointGroupWrapper {
+	if w.parent == nil {
+		panic(fmt.Sprintf("Error router building: group \"%s\" isn't a sub group", w.groupName))
+	}
+	return w.parent
+}
+
 func (w *EndPointGroupWrapper) EndGroup() *EndPointWrapper {
+	if w.parent != nil {
+		panic(fmt.Sprintf("Error router building: sub group \"%s\" must be closed with EndSubGroup", w.groupName))
+	}
 	return w.endpointWrapper
 }
+
+func (w *EndPointGroupWrapper) group() OptionsGroup {
+	if w.parent == nil {
+		return w.endpointWrapper.endpoint.groups.groups[w.groupName]
+	}
+	return w.parent.group().Groups[w.groupName]
+}
+
+func addVariantGroup(owner string, options map[string]Option, groups map[string]OptionsGroup, discriminator, value string) string {
+	if value == "" {
+		panic(fmt.Sprintf("Error router building: \"%s\" variant of \"%s\" must have a value", owner, discriminator))
+	}
+
+	option, exist := options[discriminator]
+	if !exist {
+		option = NewOption(discriminator, Enum, false)
+	}
+	if option.Type != Enum {
+		panic(fmt.Sprintf("Error router building: discriminator \"%s\" with type %s must be Enum", discriminator, option.Type))
+	}
+	if !slices.Contains(option.Choices, value) {
+		option.Choices = append(option.Choices, value)
+	}
+	options[discriminator] = option
+
+	name := discriminator + "=" + value
+	group := NewOptionsGroup("--"+discriminator, false)
+	group.Value = value
+	groups[name] = group
+	return name
+}
+
+func requireNumericOption(option Option) {
+	optionType := option.Type
+	if isCollectionOptionType(optionType) {
+		optionType = option.Elem
+	}
+	if !isNumericOptionType(optionType) {
+		panic(fmt.Sprintf("Error router building: option \"%s\" with type %s can't have numeric range", option.Name, option.Type))
+	}
+}
+
+func newCollectionOption(name string, optType, elem OptionType, required bool) Option {
+	if elem == Bool || isCollectionOptionType(elem) {
+		panic(fmt.Sprintf("Error router building: option \"%s\" can't have %s elements", name, elem))
+	}
+	option := NewOption(name, optType, required)
+	option.Elem = elem
+	option.Separator = defaultListSeparator
+	return option
+}
@@ -19,6 +19,16 @@ func mk(input string, t *testing.T) (*ctx.Context, *RoutingIterator) {
 	return c, it
 }
 
+func routeInput(t *testing.T, r *Router, input string) error {
+	t.Helper()
+	c, it := mk(input, t)
+
+	var gotErr error
+	r.CustomErrorHandler(func(err error, _ ctx.Context) { gotErr = err })
+	r.Route(*c, it)
+	return gotErr
+}
+
 func TestRoute_SimpleEndpoint_HandlerCalledAndValuesParsed(t *testing.T) {
 	c, it := mk("server --host=localhost --port=8080 --debug", t)
 
@@ -205,3 +215,294 @@ func TestRoute_NestedCommands_HandlerReached(t *testing.T) {
 		t.Fatalf("nested handler was not called")
 	}
 }
+
+func TestRoute_OptionalOptionsMissing_HandlerCalled(t *testing.T) {
+	c, it := mk("server --host=localhost", t)
+	r := NewRouter()
+	r.CustomErrorHandler(func(err error, _ ctx.Context) { t.Fatalf("unexpected error: %v", err) })
+
+	called := false
+	r.Endpoint("server").
+		StringOption("host").
+		IntOption("port").
+		FloatOption("ratio").
+		Handler(func(ctx.Context) error {
+			called = true
+			return nil
+		}).
+		Register()
+
+	r.Route(*c, it)
+
+	if !called {
+		t.Fatalf("handler was not called")
+	}
+}
+
+func TestRoute_UnitOptions_InvalidValueShowsAcceptedFormats(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{"sync --timeout=30", "accepted formats: 300ms"},
+		{"sync --since=yesterday", "accepted formats: 2006-01-02T15:04:05Z07:00"},
+		{"sync --max-size=10XB", "accepted formats: 1024, 512B"},
+	}
+
+	for _, tc := range cases {
+		c, it := mk(tc.input, t)
+		r := NewRouter()
+
+		var gotErr error
+		r.CustomErrorHandler(func(err error, _ ctx.Context) { gotErr = err })
+		r.Endpoint("sync").
+			DurationOption("timeout").
+			TimestampOption("since").
+			ByteSizeOption("max-size").
+			Handler(func(ctx.Context) error { return nil }).
+			Register()
+
+		r.Route(*c, it)
+
+		if gotErr == nil || !strings.Contains(gotErr.Error(), tc.want) {
+			t.Errorf("%s: unexpected error: %v", tc.input, gotErr)
+		}
+	}
+}
+
+func TestRoute_UnitOptions_ValidValuesPassed(t *testing.T) {
+	c, it := mk("sync --timeout=5m --since=2024-05-01T10:00:00Z --max-size=2GB", t)
+	r := NewRouter()
+	r.CustomErrorHandler(func(err error, _ ctx.Context) { t.Fatalf("unexpected error: %v", err) })
+
+	called := false
+	r.Endpoint("sync").
+		DurationOption("timeout").
+		TimestampOption("since").
+		RequiredByteSize("max-size").
+		Handler(func(cc ctx.Context) error {
+			size, _ := cc.GetValueAsByteSize("max-size")
+			if size != 2000000000 {
+				t.Errorf("max-size = %d, want 2000000000", size)
+			}
+			called = true
+			return nil
+		}).
+		Register()
+
+	r.Route(*c, it)
+
+	if !called {
+		t.Fatalf("handler was not called")
+	}
+}
+
+func buildPlatformEndpoint(r *Router) *EndPointWrapper {
+	return r.Endpoint("deploy").
+		RequiredEnum("platform", "docker", "kubernetes").
+		Variant("platform", "docker").
+		RequiredString("image").
+		EndGroup().
+		Variant("platform", "kubernetes").
+		RequiredString("namespace").
+		IntOption("replicas").
+		EndGroup().
+		Variant("platform", "serverless").
+		RequiredString("region").
+		EndGroup().
+		Group("resources", "--resources").
+		IntOption("memory").
+		EndGroup()
+}
+
+func TestRoute_VariantGroups_SelectedByDiscriminatorValue(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{"deploy --platform=kubernetes --namespace=prod --replicas=3 --resources --memory=512", ""},
+		{"deploy --platform=docker --image=app:1", ""},
+		{"deploy --platform=serverless --region=eu", ""},
+		{"deploy --platform=kubernetes --image=app:1", "Required 'namespace' flag not exist"},
+		{"deploy --platform=vm", "have unknown value \"vm\", choices: docker, kubernetes, serverless"},
+		{"deploy --image=app:1", "Required 'platform' flag not exist"},
+	}
+
+	for _, tc := range cases {
+		c, it := mk(tc.input, t)
+		r := NewRouter()
+
+		var gotErr error
+		r.CustomErrorHandler(func(err error, _ ctx.Context) { gotErr = err })
+		buildPlatformEndpoint(r).Handler(func(ctx.Context) error { return nil }).Register()
+		r.Route(*c, it)
+
+		if tc.want == "" && gotErr != nil {
+			t.Errorf("%s: unexpected error: %v", tc.input, gotErr)
+		}
+		if tc.want != "" && (gotErr == nil || !strings.Contains(gotErr.Error(), tc.want)) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.input, tc.want, gotErr)
+		}
+	}
+}
+
+func TestRoute_ExclusiveGroupWithInclusiveGroup_Rejected(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		c, it := mk("deploy --docker --image=app --resources --memory=512", t)
+		r := NewRouter()
+
+		var gotErr error
+		r.CustomErrorHandler(func(err error, _ ctx.Context) { gotErr = err })
+		r.Endpoint("deploy").
+			ExclusiveGroup("docker", "--docker").
+			RequiredString("image").
+			EndGroup().
+			ExclusiveGroup("kubernetes", "--kubernetes").
+			RequiredString("namespace").
+			EndGroup().
+			Group("resources", "--resources").
+			IntOption("memory").
+			EndGroup().
+			Handler(func(ctx.Context) error { return nil }).
+			Register()
+		r.Route(*c, it)
+
+		want := "Routing error: Exclusive group requires solitude, can't use resources group together with docker group"
+		if gotErr == nil || gotErr.Error() != want {
+			t.Fatalf("expected %q, got %v", want, gotErr)
+		}
+	}
+}
+
+func TestHelp_WithVariantGroups_RendersEachVariant(t *testing.T) {
+	help := buildPlatformEndpoint(NewRouter()).endpoint.Help()
+	for _, want := range []string{
+		"--platform=<docker|kubernetes|serverless>",
+		"Variant docker (--platform=docker):",
+		"Variant kubernetes (--platform=kubernetes):",
+		"--namespace=<string>",
+		"Group resources (--resources):",
+	} {
+		if !strings.Contains(help, want) {
+			t.Errorf("help does not contain %q:\n%s", want, help)
+		}
+	}
+}
+
+func buildStorageEndpoint(r *Router) *EndPointWrapper {
+	return r.Endpoint("backup").
+		ExclusiveGroup("s3", "--s3").
+		RequiredString("bucket").
+		SubGroup("sse", "--sse").
+		RequiredString("kms-key").
+		EndSubGroup().
+		ExclusiveSubGroup("glacier", "--glacier").
+		StringOption("vault").
+		EndSubGroup().
+		ExclusiveSubGroup("deep", "--deep-archive").
+		EndSubGroup().
+		EndGroup().
+		ExclusiveGroup("local", "--local").
+		RequiredString("path").
+		EndGroup()
+}
+
+func TestRoute_NestedGroups_ValidatedRecursively(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{"backup --s3 --bucket=data", ""},
+		{"backup --s3 --bucket=data --sse --kms-key=key-1", ""},
+		{"backup --s3 --bucket=data --sse --kms-key=key-1 --glacier", "can't use sse group together with glacier group"},
+		{"backup --s3 --bucket=data --sse", "Required 'kms-key' flag not exist"},
+		{"backup --local --path=/tmp --sse", ""},
+		{"backup --s3 --bucket=data --glacier --deep-archive", "can't use glacier group together with deep group"},
+		{"backup --s3 --bucket=data --local --path=/tmp", "can't use s3 group together with local group"},
+	}
+
+	for _, tc := range cases {
+		c, it := mk(tc.input, t)
+		r := NewRouter()
+
+		var gotErr error
+		r.CustomErrorHandler(func(err error, _ ctx.Context) { gotErr = err })
+		buildStorageEndpoint(r).Handler(func(ctx.Context) error { return nil }).Register()
+		r.Route(*c, it)
+
+		if tc.want == "" && gotErr != nil {
+			t.Errorf("%s: unexpected error: %v", tc.input, gotErr)
+		}
+		if tc.want != "" && (gotErr == nil || !strings.Contains(gotErr.Error(), tc.want)) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.input, tc.want, gotErr)
+		}
+	}
+}
+
+func TestHelp_WithNestedGroups_RendersIndentedSections(t *testing.T) {
+	help := buildStorageEndpoint(NewRouter()).endpoint.Help()
+	for _, want := range []string{
+		"Exclusive group s3 (--s3):\n  --bucket=<string>",
+		"\n  Group sse (--sse):\n    --kms-key=<string>",
+		"\n  Exclusive group glacier (--glacier):\n    --vault=<string>",
+	} {
+		if !strings.Contains(help, want) {
+			t.Errorf("help does not contain %q:\n%s", want, help)
+		}
+	}
+}
+
+func TestEndGroup_WithOpenSubGroup_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic when closing sub group with EndGroup")
+		}
+	}()
+
+	NewRouter().Endpoint("backup").Group("s3", "--s3").SubGroup("sse", "--sse").EndGroup()
+}
+
+func TestRoute_DefaultAndEnvBuilders_FillMissingOptions(t *testing.T) {
+	t.Setenv("CMD_TEST_REGION", "eu-west-1")
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{"serve", "8080  "},
+		{"serve --port=9000", "9000  "},
+		{"serve --s3 --bucket=b", "8080 eu-west-1 standard"},
+		{"serve --s3 --bucket=b --region=us --class=cold", "8080 us cold"},
+	}
+
+	for _, tc := range cases {
+		c, it := mk(tc.input, t)
+		r := NewRouter()
+		r.CustomErrorHandler(func(err error, _ ctx.Context) { t.Fatalf("%s: unexpected error: %v", tc.input, err) })
+
+		var got string
+		r.Endpoint("serve").
+			IntOption("port").
+			Default("port", "8080").
+			Group("s3", "--s3").
+			RequiredString("bucket").
+			RequiredString("region").
+			Env("region", "CMD_TEST_REGION").
+			StringOption("class").
+			Default("class", "standard").
+			EndGroup().
+			Handler(func(cc ctx.Context) error {
+				values := make([]string, 0, 3)
+				for _, name := range []string{"port", "region", "class"} {
+					values = append(values, cc.GetValueOrDefault(name, ""))
+				}
+				got = strings.Join(values, " ")
+				return nil
+			}).
+			Register()
+		r.Route(*c, it)
+
+		if got != tc.want {
+			t.Errorf("%s: values %q, want %q", tc.input, got, tc.want)
+		}
+	}
+}
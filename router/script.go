@@ -0,0 +1,152 @@
+package router
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+	prs "github.com/DilemaFixer/Cmd/parser"
+)
+
+type ScriptErrorPolicy int
+
+const (
+	StopOnError ScriptErrorPolicy = iota
+	ContinueOnError
+	CollectErrors
+)
+
+const defaultScriptName = "script"
+
+func (r *Router) ScriptErrorPolicy(policy ScriptErrorPolicy) {
+	r.scriptPolicy = policy
+}
+
+func (r *Router) ScriptErrorReporter(report func(error)) {
+	if report == nil {
+		return
+	}
+	r.scriptReport = report
+}
+
+func basicScriptReport(err error) {
+	fmt.Fprintf(os.Stderr, "Error: %s\n", prs.FormatError(err))
+}
+
+func (r *Router) ScriptCommand(name string) {
+	r.Endpoint(name).
+		Description("Run commands from a script file").
+		Handler(func(context ctx.Context) error {
+			args := context.GetSubcommandsAsArr()
+			if len(args) != 1 {
+				return fmt.Errorf("Routing error: %s expects one script file, got %d", name, len(args))
+			}
+			path, err := context.ResolvePath(args[0])
+			if err != nil {
+				return err
+			}
+			return r.RunScriptFile(path)
+		}).
+		Register()
+}
+
+func (r *Router) RunScriptFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("Routing error: can't open script %s: %w", path, err)
+	}
+	defer file.Close()
+	return r.RunScript(file)
+}
+
+func (r *Router) RunScript(script io.Reader) error {
+	name := defaultScriptName
+	if named, ok := script.(interface{ Name() string }); ok {
+		name = named.Name()
+	}
+
+	if abs, err := filepath.Abs(name); err == nil && name != defaultScriptName {
+		if slices.Contains(r.scripts, abs) {
+			return fmt.Errorf("Routing error: script %s runs itself (%s)", name, strings.Join(append(r.scripts, abs), " -> "))
+		}
+		r.scripts = append(r.scripts, abs)
+		defer func() { r.scripts = r.scripts[:len(r.scripts)-1] }()
+	}
+
+	var failures []error
+	var command strings.Builder
+	start := 0
+
+	scanner := bufio.NewScanner(script)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if command.Len() == 0 {
+			start = number
+			if trimmed := strings.TrimSpace(line); trimmed == "" || strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+		}
+
+		command.WriteString(line)
+		if isContinuedLine(line) {
+			command.WriteString("\n")
+			continue
+		}
+
+		err := r.runScriptLine(name, start, command.String())
+		command.Reset()
+		if err == nil {
+			continue
+		}
+		if r.scriptPolicy == StopOnError {
+			return err
+		}
+		failures = append(failures, r.reportScriptError(err))
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("Routing error: can't read script %s: %w", name, err)
+	}
+	if command.Len() > 0 {
+		if err := r.runScriptLine(name, start, strings.TrimSuffix(command.String(), "\\\n")); err != nil {
+			failures = append(failures, r.reportScriptError(err))
+		}
+	}
+
+	if r.scriptPolicy == ContinueOnError && len(failures) > 0 {
+		return fmt.Errorf("Routing error: %s: %d script lines failed", name, len(failures))
+	}
+	return errors.Join(failures...)
+}
+
+func (r *Router) reportScriptError(err error) error {
+	if r.scriptPolicy == ContinueOnError {
+		r.scriptReport(err)
+	}
+	return err
+}
+
+func (r *Router) runScriptLine(name string, number int, line string) error {
+	steps, err := prs.ParseSequenceWithOptions(line, prs.ParseOptions{Comments: true})
+	var parseErr *prs.ParseError
+	if errors.As(err, &parseErr) {
+		number += parseErr.Line - 1
+	}
+	if err == nil {
+		err = r.RunSequence(steps)
+	}
+	if err != nil {
+		return fmt.Errorf("%s:%d: %w", name, number, err)
+	}
+	return nil
+}
+
+func isContinuedLine(line string) bool {
+	backslashes := len(line) - len(strings.TrimRight(line, "\\"))
+	return backslashes%2 == 1
+}
@@ -0,0 +1,108 @@
+package router
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	prs "github.com/DilemaFixer/Cmd/parser"
+)
+
+const testScript = `# deploy runbook
+ok --name=1
+
+  # indented comment
+ok \
+  --name=2
+fail
+ok --name=3 && fail || ok --name=4
+ok --name="5
+`
+
+func TestRunScript_RunsLinesAndReportsFileLine(t *testing.T) {
+	calls := make([]string, 0)
+	err := sequenceRouter(&calls).RunScript(strings.NewReader(testScript))
+
+	if !reflect.DeepEqual(calls, []string{"ok1", "ok2", "fail"}) {
+		t.Fatalf("unexpected calls %v", calls)
+	}
+	if err == nil || err.Error() != "script:7: failed" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestRunScript_WithErrorPolicies(t *testing.T) {
+	calls := make([]string, 0)
+	reported := make([]error, 0)
+	r := sequenceRouter(&calls)
+	r.ScriptErrorPolicy(ContinueOnError)
+	r.ScriptErrorReporter(func(err error) { reported = append(reported, err) })
+
+	err := r.RunScript(strings.NewReader(testScript + "ok --name=6\n"))
+	if !reflect.DeepEqual(calls, []string{"ok1", "ok2", "fail", "ok3", "fail", "ok4", "ok6"}) {
+		t.Fatalf("unexpected calls %v", calls)
+	}
+	if err == nil || err.Error() != "Routing error: script: 2 script lines failed" {
+		t.Fatalf("expected failure summary despite passing last line, got %v", err)
+	}
+	var parseErr *prs.ParseError
+	if len(reported) != 2 || reported[0].Error() != "script:7: failed" || !errors.As(reported[1], &parseErr) {
+		t.Fatalf("expected each failure to be reported, got %v", reported)
+	}
+
+	calls = calls[:0]
+	r.ScriptErrorPolicy(CollectErrors)
+	err = r.RunScript(strings.NewReader(testScript))
+	lines := strings.Split(err.Error(), "\n")
+	if len(lines) != 2 || lines[0] != "script:7: failed" || !strings.HasPrefix(lines[1], "script:9: ") {
+		t.Fatalf("unexpected collected errors %q", lines)
+	}
+
+	calls = calls[:0]
+	if err := r.RunScript(strings.NewReader("ok --name=1\nok --name=2 \\")); err != nil || len(calls) != 2 {
+		t.Fatalf("expected trailing continuation to run, got %v and %v", err, calls)
+	}
+}
+
+func TestRunScript_TrailingCommentsAndContinuedErrorLines(t *testing.T) {
+	calls := make([]string, 0)
+	script := "ok --name=1  # note\nok --name='#2' # note; fail\nok --name=a#3\nok \\\n  --name=4 \\\n  --name=\"5\n"
+	err := sequenceRouter(&calls).RunScript(strings.NewReader(script))
+
+	if !reflect.DeepEqual(calls, []string{"ok1", "ok#2", "oka#3"}) {
+		t.Fatalf("unexpected calls %v", calls)
+	}
+	if err == nil || !strings.HasPrefix(err.Error(), "script:6: ") {
+		t.Fatalf("expected error on the line of the open quote, got %v", err)
+	}
+}
+
+func TestScriptCommand_RunsFileRelativeToWorkDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "steps.txt"), []byte("ok --name=1\nrun steps.txt\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := make([]string, 0)
+	r := sequenceRouter(&calls)
+	r.WorkDir(dir)
+	r.ScriptCommand("run")
+
+	err := r.Execute("run steps.txt")
+	if !reflect.DeepEqual(calls, []string{"ok1"}) {
+		t.Fatalf("unexpected calls %v", calls)
+	}
+	if err == nil || !strings.Contains(err.Error(), "steps.txt:2: Routing error: script") || !strings.Contains(err.Error(), "runs itself") {
+		t.Fatalf("expected recursion error with file:line, got %v", err)
+	}
+
+	if err := r.Execute("run missing.txt"); err == nil || !strings.Contains(err.Error(), "can't open script") {
+		t.Fatalf("expected open error, got %v", err)
+	}
+	if err := r.Execute("run"); err == nil || !strings.Contains(err.Error(), "expects one script file") {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package router
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+	"github.com/DilemaFixer/Cmd/internal/term"
+)
+
+const secretFileSuffix = "-file"
+
+func resolveSecretFile(option Option, context ctx.Context) error {
+	context.MarkSecret(option.Name)
+
+	fileFlag := option.Name + secretFileSuffix
+	if !context.IsFlagExist(fileFlag) {
+		return nil
+	}
+	if context.IsFlagExist(option.Name) {
+		return fmt.Errorf("Routing error: Option %s can't be used together with --%s", option.Name, fileFlag)
+	}
+	if !context.IsFlagHaveValue(fileFlag) {
+		return fmt.Errorf("Routing error: Option %s haven't value, must look like --%s=<file>", fileFlag, fileFlag)
+	}
+
+	path, _ := context.GetValueAsPath(fileFlag)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Routing error: Option %s can't read secret file: %s", option.Name, err.Error())
+	}
+	context.RemoveFlag(fileFlag)
+	context.SetFlag(option.Name, strings.TrimRight(string(content), "\r\n"))
+	return nil
+}
+
+func promptSecret(option Option, context ctx.Context) bool {
+	if !option.Secret {
+		return false
+	}
+	terminal, ok := terminalOf(context.GetStdin())
+	if !ok || !term.IsTerminal(terminal.Fd()) {
+		return false
+	}
+
+	fmt.Fprintf(os.Stderr, "%s: ", option.Name)
+	value, err := term.ReadPassword(terminal.Fd())
+	fmt.Fprintln(os.Stderr)
+	if err != nil || len(value) == 0 {
+		return false
+	}
+	context.SetFlag(option.Name, string(value))
+	return true
+}
+
+func terminalOf(stdin io.Reader) (*os.File, bool) {
+	switch stdin := stdin.(type) {
+	case *os.File:
+		return stdin, true
+	case shellStdin:
+		return stdin.terminal()
+	}
+	return nil, false
+}
+
+func maskSecretError(option Option, context ctx.Context, err error) error {
+	if !option.Secret {
+		return err
+	}
+	return maskSecrets(context, err)
+}
+
+func maskSecrets(context ctx.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if message := context.MaskSecrets(err.Error()); message != err.Error() {
+		return &maskedError{err: err, message: message}
+	}
+	return err
+}
+
+type maskedError struct {
+	err     error
+	message string
+}
+
+func (err *maskedError) Error() string {
+	return err.message
+}
+
+func (err *maskedError) Unwrap() error {
+	return err.err
+}
@@ -0,0 +1,169 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+)
+
+func routeSecretInput(t *testing.T, workDir, input string, handler func(ctx.Context) error) error {
+	t.Helper()
+	r := NewRouter()
+	r.WorkDir(workDir)
+	r.Stdin(strings.NewReader(""))
+	r.Endpoint("connect").
+		RequiredSecret("password").
+		MinLength("password", 8).
+		SecretOption("token").
+		Handler(handler).
+		Register()
+	return routeInput(t, r, input)
+}
+
+func TestRoute_SecretOption_ReadFromFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "pass.txt"), []byte("correct-horse\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var password string
+	var flags []string
+	err := routeSecretInput(t, dir, "connect --password-file=pass.txt", func(cc ctx.Context) error {
+		password, _ = cc.GetValueAsString("password")
+		flags = cc.GetFlagsAsArr()
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if password != "correct-horse" {
+		t.Errorf("password = %q, want value from file", password)
+	}
+	if strings.Join(flags, " ") != "password="+ctx.SecretMask {
+		t.Errorf("flags = %v, want masked password only", flags)
+	}
+}
+
+func TestRoute_SecretOption_FromEnv(t *testing.T) {
+	t.Setenv("CMD_TEST_TOKEN", "env-token")
+	c, it := mk("connect", t)
+	r := NewRouter()
+	r.CustomErrorHandler(func(err error, _ ctx.Context) { t.Fatalf("unexpected error: %v", err) })
+
+	var token string
+	r.Endpoint("connect").
+		RequiredSecret("token").
+		Env("token", "CMD_TEST_TOKEN").
+		Handler(func(cc ctx.Context) error {
+			token, _ = cc.GetValueAsString("token")
+			return nil
+		}).
+		Register()
+	r.Route(*c, it)
+
+	if token != "env-token" {
+		t.Fatalf("token = %q, want value from env", token)
+	}
+}
+
+func TestRoute_GroupSecretOption_FromEnv(t *testing.T) {
+	t.Setenv("CMD_TEST_S3_KEY", "env-key")
+	c, it := mk("backup --s3", t)
+	r := NewRouter()
+	r.CustomErrorHandler(func(err error, _ ctx.Context) { t.Fatalf("unexpected error: %v", err) })
+
+	var key string
+	r.Endpoint("backup").
+		Group("s3", "--s3").
+		RequiredSecret("key").
+		Env("key", "CMD_TEST_S3_KEY").
+		EndGroup().
+		Handler(func(cc ctx.Context) error {
+			key, _ = cc.GetValueAsString("key")
+			return nil
+		}).
+		Register()
+	r.Route(*c, it)
+
+	if key != "env-key" {
+		t.Fatalf("key = %q, want value from env", key)
+	}
+}
+
+func TestRoute_SecretOption_ErrorsDoNotLeakValue(t *testing.T) {
+	dir := t.TempDir()
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{"connect --password=short", "Option password value \"******\" violates min length 8"},
+		{"connect --password=long-enough --password-file=pass.txt", "can't be used together with --password-file"},
+		{"connect --password-file=missing.txt", "can't read secret file"},
+		{"connect", "Required 'password' flag not exist"},
+	}
+
+	for _, tc := range cases {
+		err := routeSecretInput(t, dir, tc.input, func(ctx.Context) error { return nil })
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.input, tc.want, err)
+		}
+		if err != nil && strings.Contains(err.Error(), "short") {
+			t.Errorf("%s: error leaks secret value: %v", tc.input, err)
+		}
+	}
+}
+
+func TestRoute_SecretOption_HandlerErrorIsMasked(t *testing.T) {
+	err := routeSecretInput(t, t.TempDir(), "connect --password=hunter22", func(cc ctx.Context) error {
+		password, _ := cc.GetValueAsString("password")
+		return fmt.Errorf("login rejected with password %s", password)
+	})
+
+	if err == nil || err.Error() != "login rejected with password "+ctx.SecretMask {
+		t.Fatalf("expected masked handler error, got %v", err)
+	}
+}
+
+func TestRoute_SecretOption_MaskedErrorKeepsChain(t *testing.T) {
+	rejected := errors.New("rejected")
+	err := routeSecretInput(t, t.TempDir(), "connect --password=hunter22", func(cc ctx.Context) error {
+		return fmt.Errorf("password hunter22: %w", &os.PathError{Op: "open", Path: "hunter22", Err: rejected})
+	})
+
+	if err == nil || strings.Contains(err.Error(), "hunter22") {
+		t.Fatalf("expected masked error, got %v", err)
+	}
+	var pathErr *os.PathError
+	if !errors.Is(err, rejected) || !errors.As(err, &pathErr) {
+		t.Errorf("masked error lost its chain: %v", err)
+	}
+}
+
+func TestTerminalOf_WithoutTerminal_ReturnsFalse(t *testing.T) {
+	for _, stdin := range []io.Reader{strings.NewReader(""), shellStdin{strings.NewReader("")}} {
+		if _, ok := terminalOf(stdin); ok {
+			t.Errorf("%T: expected no terminal", stdin)
+		}
+	}
+	if file, ok := terminalOf(shellStdin{}); !ok || file != os.Stdin {
+		t.Errorf("expected the shell to prompt on os.Stdin, got %v", file)
+	}
+}
+
+func TestHelp_WithSecretOption_RendersFileSource(t *testing.T) {
+	w := NewRouter().Endpoint("connect").RequiredSecret("password")
+
+	if help := w.endpoint.Help(); !strings.Contains(help, "(required, secret, or --password-file=<file>)") {
+		t.Errorf("unexpected help:\n%s", help)
+	}
+	if got := w.endpoint.completeOption("--pass", ""); strings.Join(got, " ") != "--password= --password-file=" {
+		t.Errorf("unexpected completion %v", got)
+	}
+}
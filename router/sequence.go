@@ -0,0 +1,37 @@
+package router
+
+import (
+	ctx "github.com/DilemaFixer/Cmd/context"
+	prs "github.com/DilemaFixer/Cmd/parser"
+)
+
+func (r *Router) Execute(line string) error {
+	steps, err := prs.ParseSequence(line)
+	if err != nil {
+		return err
+	}
+	return r.RunSequence(steps)
+}
+
+func (r *Router) RunSequence(steps prs.Sequence) error {
+	return runSequence(steps, r.run)
+}
+
+func (r *Router) run(input *prs.ParsedInput) error {
+	context := ctx.NewContext(input)
+	return r.dispatch(*context, NewRoutingIterator(context))
+}
+
+func runSequence(steps prs.Sequence, run func(*prs.ParsedInput) error) error {
+	var err error
+	for i, step := range steps {
+		if i > 0 && step.Operator == prs.AndOperator && err != nil {
+			continue
+		}
+		if i > 0 && step.Operator == prs.OrOperator && err == nil {
+			continue
+		}
+		err = run(step.Input)
+	}
+	return err
+}
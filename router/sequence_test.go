@@ -0,0 +1,67 @@
+package router
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+)
+
+func sequenceRouter(calls *[]string) *Router {
+	r := NewRouter()
+	r.CustomErrorHandler(func(err error, context ctx.Context) {})
+	r.Endpoint("ok").
+		StringOption("name").
+		Handler(func(context ctx.Context) error {
+			name, _ := context.GetValueAsString("name")
+			*calls = append(*calls, "ok"+name)
+			return nil
+		}).
+		Register()
+	r.Endpoint("fail").
+		Handler(func(context ctx.Context) error {
+			*calls = append(*calls, "fail")
+			return fmt.Errorf("failed")
+		}).
+		Register()
+	return r
+}
+
+func TestExecute_HonoursChainOperators(t *testing.T) {
+	cases := []struct {
+		line  string
+		calls []string
+		err   string
+	}{
+		{"ok --name=1 && ok --name=2", []string{"ok1", "ok2"}, ""},
+		{"fail && ok --name=1", []string{"fail"}, "failed"},
+		{"fail || ok --name=1", []string{"fail", "ok1"}, ""},
+		{"ok --name=1 || ok --name=2", []string{"ok1"}, ""},
+		{"fail; ok --name=1", []string{"fail", "ok1"}, ""},
+		{"ok --name=1; fail", []string{"ok1", "fail"}, "failed"},
+		{"fail && ok --name=1 || ok --name=2", []string{"fail", "ok2"}, ""},
+		{"ok --name=1 && missing || ok --name=2", []string{"ok1", "ok2"}, ""},
+		{"ok --name=1 && ok --name && ok --name=3", []string{"ok1"}, "Routing error"},
+	}
+
+	for _, tc := range cases {
+		calls := make([]string, 0)
+		err := sequenceRouter(&calls).Execute(tc.line)
+
+		if !reflect.DeepEqual(calls, tc.calls) {
+			t.Errorf("%q ran %v, want %v", tc.line, calls, tc.calls)
+		}
+		if tc.err == "" && err != nil || tc.err != "" && (err == nil || !strings.Contains(err.Error(), tc.err)) {
+			t.Errorf("%q returned %v, want %q", tc.line, err, tc.err)
+		}
+	}
+}
+
+func TestExecute_WithParseError_RunsNothing(t *testing.T) {
+	calls := make([]string, 0)
+	if err := sequenceRouter(&calls).Execute("ok && && ok"); err == nil || len(calls) != 0 {
+		t.Fatalf("expected parse error and no calls, got %v and %v", err, calls)
+	}
+}
@@ -0,0 +1,438 @@
+package router
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+	prs "github.com/DilemaFixer/Cmd/parser"
+)
+
+type LineReader interface {
+	ReadLine(prompt string) (string, error)
+}
+
+type historyReader interface {
+	AddHistory(line string)
+}
+
+type plainLineReader struct {
+	input  *bufio.Reader
+	output io.Writer
+}
+
+func NewLineReader(input io.Reader, output io.Writer) LineReader {
+	return &plainLineReader{input: bufio.NewReader(input), output: output}
+}
+
+func (reader *plainLineReader) ReadLine(prompt string) (string, error) {
+	fmt.Fprint(reader.output, prompt)
+	line, err := reader.input.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+var shellBuiltins = []string{"cd", "exit", "help", "quit"}
+
+var errShellStdin = errors.New("stdin is used by the interactive shell")
+
+type shellStdin struct {
+	stdin io.Reader
+}
+
+func (stdin shellStdin) Read([]byte) (int, error) {
+	return 0, errShellStdin
+}
+
+func (stdin shellStdin) terminal() (*os.File, bool) {
+	if stdin.stdin == nil {
+		return os.Stdin, true
+	}
+	file, ok := stdin.stdin.(*os.File)
+	return file, ok
+}
+
+type Shell struct {
+	router      *Router
+	reader      LineReader
+	output      io.Writer
+	name        string
+	historyFile string
+	history     []string
+	path        []string
+	exiting     bool
+}
+
+func NewShell(router *Router) *Shell {
+	return &Shell{
+		router:  router,
+		output:  os.Stdout,
+		name:    filepath.Base(os.Args[0]),
+		history: make([]string, 0),
+	}
+}
+
+func (r *Router) ShellCommand(name string) *Shell {
+	shell := NewShell(r)
+	r.Endpoint(name).
+		Description("Start an interactive shell").
+		Handler(func(context ctx.Context) error {
+			return shell.Run()
+		}).
+		Register()
+	return shell
+}
+
+func (shell *Shell) Reader(reader LineReader) *Shell {
+	shell.reader = reader
+	return shell
+}
+
+func (shell *Shell) Output(output io.Writer) *Shell {
+	shell.output = output
+	return shell
+}
+
+func (shell *Shell) Name(name string) *Shell {
+	shell.name = name
+	return shell
+}
+
+func (shell *Shell) HistoryFile(path string) *Shell {
+	shell.historyFile = path
+	return shell
+}
+
+func (shell *Shell) History() []string {
+	return append([]string(nil), shell.history...)
+}
+
+func (shell *Shell) Prompt() string {
+	return strings.Join(append([]string{shell.name}, shell.path...), " ") + "> "
+}
+
+func (shell *Shell) Run() error {
+	if shell.reader == nil {
+		var stdin io.Reader = os.Stdin
+		if shell.router.stdin != nil {
+			stdin = shell.router.stdin
+		}
+		shell.reader = NewTerminalReader(stdin, shell.output, shell.Complete)
+	}
+	if err := shell.loadHistory(); err != nil {
+		return err
+	}
+	if reader, ok := shell.reader.(historyReader); ok {
+		for _, line := range shell.history {
+			reader.AddHistory(line)
+		}
+	}
+
+	shell.exiting = false
+	for !shell.exiting {
+		line, err := shell.reader.ReadLine(shell.Prompt())
+		if err == io.EOF {
+			fmt.Fprintln(shell.output)
+			return nil
+		}
+		if err == ErrInterrupted {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if err := shell.addHistory(line); err != nil {
+			fmt.Fprintf(shell.output, "Error: %s\n", err.Error())
+		}
+		if err := shell.Execute(line); err != nil {
+			fmt.Fprintf(shell.output, "Error: %s\n", prs.FormatError(err))
+		}
+	}
+	return nil
+}
+
+func (shell *Shell) Execute(line string) error {
+	steps, err := prs.ParseSequence(line)
+	if err != nil {
+		return err
+	}
+	return runSequence(steps, shell.run)
+}
+
+func (shell *Shell) run(input *prs.ParsedInput) error {
+	switch input.Command {
+	case "exit", "quit":
+		shell.exiting = true
+		return nil
+	case "cd":
+		return shell.cd(input.Subcommands)
+	case "help":
+		return shell.help(input.Subcommands)
+	}
+	stdin := shell.router.stdin
+	shell.router.stdin = shellStdin{stdin}
+	defer func() { shell.router.stdin = stdin }()
+	return shell.router.run(shell.resolve(input))
+}
+
+func (shell *Shell) resolve(input *prs.ParsedInput) *prs.ParsedInput {
+	if len(shell.path) == 0 {
+		return input
+	}
+	if _, exist := shell.points(shell.path)[input.Command]; !exist {
+		return input
+	}
+
+	subcommands := append(append([]string{}, shell.path[1:]...), input.Command)
+	return &prs.ParsedInput{
+		Command:     shell.path[0],
+		Subcommands: append(subcommands, input.Subcommands...),
+		InputFlags:  input.InputFlags,
+	}
+}
+
+func (shell *Shell) cd(args []string) error {
+	path := append([]string{}, shell.path...)
+	if len(args) == 0 {
+		path = path[:0]
+	}
+
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "/") {
+			path = path[:0]
+		}
+		for _, name := range strings.Split(arg, "/") {
+			switch name {
+			case "", ".":
+				continue
+			case "..":
+				if len(path) > 0 {
+					path = path[:len(path)-1]
+				}
+				continue
+			}
+
+			point, exist := shell.points(path)[name]
+			if !exist {
+				return fmt.Errorf("Routing error: Point with name %s not found ", name)
+			}
+			if _, ok := point.(*CmdPoint); !ok {
+				return fmt.Errorf("Routing error: %s is a command, not a command group", name)
+			}
+			path = append(path, name)
+		}
+	}
+
+	shell.path = path
+	return nil
+}
+
+func (shell *Shell) help(args []string) error {
+	points := shell.points(shell.path)
+	if len(args) > 0 {
+		if _, exist := points[args[0]]; !exist {
+			points = shell.router.points
+		}
+		for i, name := range args {
+			point, exist := points[name]
+			if !exist {
+				return fmt.Errorf("Routing error: Point with name %s not found ", name)
+			}
+			switch p := point.(type) {
+			case *EndPoint:
+				if i == len(args)-1 {
+					fmt.Fprint(shell.output, p.Help())
+					return nil
+				}
+				return fmt.Errorf("Routing error: %s has no subcommand %s", name, args[i+1])
+			case *CmdPoint:
+				points = p.GetAllSubCommands()
+			}
+		}
+	}
+
+	names := make([]string, 0, len(points))
+	for name := range points {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(shell.output, "Commands:")
+	writer := tabwriter.NewWriter(shell.output, 0, 0, 2, ' ', 0)
+	for _, name := range names {
+		description := "command group"
+		if endPoint, ok := points[name].(*EndPoint); ok {
+			description = endPoint.description
+		}
+		fmt.Fprintf(writer, "  %s\t%s\n", name, description)
+	}
+	writer.Flush()
+	fmt.Fprintln(shell.output, "Builtins: cd <group>, cd .., help [command], exit")
+	return nil
+}
+
+func (shell *Shell) Complete(line string) []string {
+	words := prs.CompletionWords(line)
+
+	candidates := make([]string, 0)
+	switch {
+	case len(words) == 1:
+		for _, builtin := range shellBuiltins {
+			if strings.HasPrefix(builtin, words[0]) {
+				candidates = append(candidates, builtin)
+			}
+		}
+		candidates = append(candidates, shell.completeCommand(words)...)
+	case words[0] == "cd":
+		for name, point := range shell.points(shell.path) {
+			if _, ok := point.(*CmdPoint); ok && len(words) == 2 && strings.HasPrefix(name, words[1]) {
+				candidates = append(candidates, name)
+			}
+		}
+	case words[0] == "help":
+		candidates = shell.completeCommand(words[1:])
+	case !slices.Contains(shellBuiltins, words[0]):
+		candidates = shell.completeCommand(words)
+	}
+
+	sort.Strings(candidates)
+	return slices.Compact(candidates)
+}
+
+func (shell *Shell) completeCommand(words []string) []string {
+	if len(shell.path) == 0 {
+		return shell.router.Complete(words)
+	}
+	relative := append(append([]string{}, shell.path...), words...)
+	if len(words) == 1 {
+		return append(shell.router.Complete(relative), shell.router.Complete(words)...)
+	}
+	if _, exist := shell.points(shell.path)[words[0]]; exist {
+		return shell.router.Complete(relative)
+	}
+	return shell.router.Complete(words)
+}
+
+func (shell *Shell) points(path []string) map[string]RoutePoint {
+	points := shell.router.points
+	for _, name := range path {
+		cmd, ok := points[name].(*CmdPoint)
+		if !ok {
+			return map[string]RoutePoint{}
+		}
+		points = cmd.GetAllSubCommands()
+	}
+	return points
+}
+
+func (shell *Shell) endPoint(input *prs.ParsedInput) (*EndPoint, bool) {
+	point, exist := shell.router.points[input.Command]
+	for _, name := range input.Subcommands {
+		cmd, ok := point.(*CmdPoint)
+		if !ok {
+			break
+		}
+		point, exist = cmd.GetSubCommand(name)
+	}
+	endPoint, ok := point.(*EndPoint)
+	return endPoint, exist && ok
+}
+
+func (shell *Shell) maskSecrets(line string) (string, bool) {
+	steps, err := prs.ParseSequence(line)
+	if err != nil {
+		return "", false
+	}
+	masked, err := prs.MaskFlagValues(line, ctx.SecretMask, func(step int, name string) bool {
+		endPoint, exist := shell.endPoint(shell.resolve(steps[step].Input))
+		if !exist {
+			return false
+		}
+		for _, option := range endPoint.allOptions() {
+			if option.Secret && (option.Name == name || option.Short != 0 && string(option.Short) == name) {
+				return true
+			}
+		}
+		return false
+	})
+	return masked, err == nil
+}
+
+func (shell *Shell) loadHistory() error {
+	if shell.historyFile == "" {
+		return nil
+	}
+	content, err := os.ReadFile(shell.historyFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Routing error: can't read history %s: %w", shell.historyFile, err)
+	}
+
+	shell.history = shell.history[:0]
+	for _, line := range strings.Split(string(content), "\n") {
+		if line != "" {
+			shell.history = append(shell.history, unescapeHistory(line))
+		}
+	}
+	return nil
+}
+
+func (shell *Shell) addHistory(line string) error {
+	line, ok := shell.maskSecrets(line)
+	if !ok {
+		return nil
+	}
+	if len(shell.history) > 0 && shell.history[len(shell.history)-1] == line {
+		return nil
+	}
+	shell.history = append(shell.history, line)
+	if reader, ok := shell.reader.(historyReader); ok {
+		reader.AddHistory(line)
+	}
+	if shell.historyFile == "" {
+		return nil
+	}
+
+	file, err := os.OpenFile(shell.historyFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("Routing error: can't save history %s: %w", shell.historyFile, err)
+	}
+	defer file.Close()
+	_, err = fmt.Fprintln(file, escapeHistory(line))
+	return err
+}
+
+func escapeHistory(line string) string {
+	return strings.NewReplacer("\\", "\\\\", "\n", "\\n").Replace(line)
+}
+
+func unescapeHistory(line string) string {
+	var unescaped strings.Builder
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' && i+1 < len(line) && (line[i+1] == '\\' || line[i+1] == 'n') {
+			i++
+			if line[i] == 'n' {
+				unescaped.WriteByte('\n')
+				continue
+			}
+		}
+		unescaped.WriteByte(line[i])
+	}
+	return unescaped.String()
+}
@@ -0,0 +1,224 @@
+package router
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+)
+
+func shellRouter(calls *[]string) *Router {
+	r := NewRouter()
+	r.CustomErrorHandler(func(err error, context ctx.Context) { panic("error handler must not be called: " + err.Error()) })
+	record := func(context ctx.Context) error {
+		name := strings.Join(append([]string{context.GetCommand()}, context.GetSubcommandsAsArr()...), " ")
+		*calls = append(*calls, name)
+		return nil
+	}
+	r.NewCmd("db").
+		NewSub("migrate").
+		Endpoint("up").
+		Description("Apply migrations").
+		IntOption("steps").
+		Handler(record).
+		Build().
+		Build().
+		Endpoint("status").
+		Description("Show status").
+		Handler(record).
+		Build().
+		Register()
+	r.Endpoint("version").Description("Show version").Handler(record).Register()
+	return r
+}
+
+func runShell(t *testing.T, r *Router, input string) (*Shell, string) {
+	t.Helper()
+	var out strings.Builder
+	shell := NewShell(r).Name("app").Output(&out).Reader(NewLineReader(strings.NewReader(input), &out))
+	if err := shell.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return shell, out.String()
+}
+
+func TestShell_RoutesLinesRelativeToCurrentGroup(t *testing.T) {
+	calls := make([]string, 0)
+	input := "version\ncd db\nstatus\ncd migrate\nup --steps=2 && version\ncd ..\nmigrate up\ncd /\ndb status\n"
+	shell, out := runShell(t, shellRouter(&calls), input)
+
+	want := []string{"version", "db status", "db migrate up", "version", "db migrate up", "db status"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Fatalf("expected %v, got %v", want, calls)
+	}
+	if !strings.HasPrefix(out, "app> app> app db> app db> app db migrate> ") {
+		t.Fatalf("unexpected prompts %q", out)
+	}
+	if prompts := strings.Count(out, "app db migrate> "); prompts != 2 {
+		t.Fatalf("expected two prompts inside db migrate, got %d in %q", prompts, out)
+	}
+	if shell.Prompt() != "app> " {
+		t.Fatalf("unexpected final prompt %q", shell.Prompt())
+	}
+}
+
+func TestShell_ReportsErrorsAndKeepsRunning(t *testing.T) {
+	calls := make([]string, 0)
+	_, out := runShell(t, shellRouter(&calls), "db up\ncd version\nversion --x=\"\nup\ncd db\nstatus\nexit\nversion\n")
+
+	if !reflect.DeepEqual(calls, []string{"db status"}) {
+		t.Fatalf("unexpected calls %v", calls)
+	}
+	for _, want := range []string{
+		"Error: Routing error: Point with name up not found",
+		"Error: Routing error: version is a command, not a command group",
+		"Error: Parsing err: unterminated double quote at column 13\nversion --x=\"\n            ^\n",
+		"Error: Routing error: try route to non-existent point up",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output %q", want, out)
+		}
+	}
+}
+
+func TestShell_HelpBuiltin(t *testing.T) {
+	calls := make([]string, 0)
+	_, out := runShell(t, shellRouter(&calls), "help\ncd db\nhelp\nhelp migrate up\nhelp version\n")
+
+	for _, want := range []string{
+		"Commands:\n  db       command group\n  version  Show version\n",
+		"Commands:\n  migrate  command group\n  status   Show status\n",
+		"up - Apply migrations\nOptions:\n",
+		"version - Show version\n",
+		"Builtins: cd <group>, cd .., help [command], exit\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output %q", want, out)
+		}
+	}
+	if len(calls) != 0 {
+		t.Fatalf("help must not run commands, got %v", calls)
+	}
+}
+
+func TestShell_PersistsHistory(t *testing.T) {
+	historyFile := filepath.Join(t.TempDir(), "history")
+	if err := os.WriteFile(historyFile, []byte("old\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := make([]string, 0)
+	var out strings.Builder
+	shell := NewShell(shellRouter(&calls)).Output(&out).HistoryFile(historyFile).
+		Reader(NewLineReader(strings.NewReader("version\nversion\n\nhelp\n"), &out))
+	if err := shell.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"old", "version", "help"}
+	if !reflect.DeepEqual(shell.History(), want) {
+		t.Fatalf("expected history %v, got %v", want, shell.History())
+	}
+	content, _ := os.ReadFile(historyFile)
+	if string(content) != "old\nversion\nhelp\n" {
+		t.Fatalf("unexpected history file %q", content)
+	}
+}
+
+func TestShell_MasksSecretsInHistory(t *testing.T) {
+	historyFile := filepath.Join(t.TempDir(), "history")
+	calls := make([]string, 0)
+	r := shellRouter(&calls)
+	r.Endpoint("login").
+		StringOption("user").
+		RequiredSecret("password").
+		Short("password", 'p').
+		Handler(func(ctx.Context) error { return nil }).
+		Register()
+
+	var out strings.Builder
+	input := "login --user=hunter2x --password=hunter2\nversion && login -p='s3cret pass'\nlogin --password=\"s3cret\n"
+	shell := NewShell(r).Output(&out).HistoryFile(historyFile).
+		Reader(NewLineReader(strings.NewReader(input), &out))
+	if err := shell.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "login --user=hunter2x --password=******\nversion && login -p='******'\n"
+	if content, _ := os.ReadFile(historyFile); string(content) != want {
+		t.Fatalf("unexpected history file %q", content)
+	}
+	if strings.Contains(strings.Join(shell.History(), "\n"), "s3cret") {
+		t.Fatalf("history keeps secret values: %v", shell.History())
+	}
+}
+
+func TestShell_HistoryKeepsContinuedLines(t *testing.T) {
+	historyFile := filepath.Join(t.TempDir(), "history")
+	calls := make([]string, 0)
+	shell := NewShell(shellRouter(&calls)).HistoryFile(historyFile)
+
+	line := "db migrate up \\\n--steps=2"
+	if err := shell.addHistory(line); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := shell.addHistory(`version c:\new`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reloaded := NewShell(shellRouter(&calls)).HistoryFile(historyFile)
+	if err := reloaded.loadHistory(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{line, `version c:\new`}; !reflect.DeepEqual(reloaded.History(), want) {
+		t.Fatalf("expected history %q, got %q", want, reloaded.History())
+	}
+}
+
+func TestShell_IndirectStdinIsRejected(t *testing.T) {
+	calls := make([]string, 0)
+	r := shellRouter(&calls)
+	r.Stdin(strings.NewReader("piped"))
+	r.Endpoint("query").
+		StringOption("sql").
+		Indirect("sql").
+		Handler(func(cc ctx.Context) error {
+			sql, _ := cc.GetValueAsString("sql")
+			calls = append(calls, "query "+sql)
+			return nil
+		}).
+		Register()
+
+	_, out := runShell(t, r, "query --sql=-\nquery --sql=@@x\n")
+	if !reflect.DeepEqual(calls, []string{"query @x"}) {
+		t.Fatalf("unexpected calls %v", calls)
+	}
+	want := "Error: Routing error: Option sql can't read value from stdin: stdin is used by the interactive shell"
+	if !strings.Contains(out, want) {
+		t.Fatalf("expected %q in output %q", want, out)
+	}
+
+	c, it := mk("query --sql=-", t)
+	r.Route(*c, it)
+	if calls[len(calls)-1] != "query piped" {
+		t.Fatalf("expected stdin to be restored after the shell, got %v", calls)
+	}
+}
+
+func TestShellCommand_StartsShellFromRouter(t *testing.T) {
+	calls := make([]string, 0)
+	r := shellRouter(&calls)
+	var out strings.Builder
+	r.ShellCommand("shell").Name("app").Output(&out)
+	r.Stdin(strings.NewReader("version\nexit\n"))
+
+	if err := r.Execute("shell"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(calls, []string{"version"}) || !strings.HasPrefix(out.String(), "app> ") {
+		t.Fatalf("unexpected calls %v and output %q", calls, out.String())
+	}
+}
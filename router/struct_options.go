@@ -0,0 +1,198 @@
+package router
+
+import (
+	"encoding"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+)
+
+const (
+	structNameTag    = ctx.BindTag
+	structHelpTag    = "help"
+	structDefaultTag = "default"
+	structEnvTag     = "env"
+	structEnumTag    = "enum"
+	structShortTag   = "short"
+)
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+func (w *EndPointWrapper) StructOptions(prototype any) *EndPointWrapper {
+	options, err := structOptions(reflect.TypeOf(prototype))
+	if err != nil {
+		panic(fmt.Sprintf("Error router building: endpoint \"%s\" %s", w.endpoint.name, err.Error()))
+	}
+
+	for _, option := range options {
+		w.endpoint.options[option.Name] = option
+	}
+	return w
+}
+
+func StructHandler[T any](handler func(ctx.Context, *T) error) func(ctx.Context) error {
+	return func(context ctx.Context) error {
+		opts := new(T)
+		if err := context.Bind(opts); err != nil {
+			return fmt.Errorf("Routing error: Options can't be bound to struct: %w", err)
+		}
+		return handler(context, opts)
+	}
+}
+
+func structOptions(t reflect.Type) ([]Option, error) {
+	if t == nil {
+		return nil, fmt.Errorf("options struct is nil")
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("options must be a struct, got %s", t.Kind())
+	}
+
+	options := make([]Option, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if _, exist := ctx.BindTagName(field.Tag); !exist {
+			continue
+		}
+		if !field.IsExported() {
+			return nil, fmt.Errorf("field %s with cmd tag must be exported", field.Name)
+		}
+
+		option, err := structFieldOption(field, field.Tag.Get(structNameTag))
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, option)
+	}
+	return options, nil
+}
+
+func structFieldOption(field reflect.StructField, tag string) (Option, error) {
+	parts := strings.Split(tag, ",")
+	name := strings.TrimSpace(parts[0])
+	if name == "" {
+		return Option{}, fmt.Errorf("field %s have empty option name", field.Name)
+	}
+
+	optionType, err := structFieldOptionType(field.Type)
+	if err != nil {
+		return Option{}, fmt.Errorf("field %s: %s", field.Name, err.Error())
+	}
+
+	option := NewOption(name, optionType, false)
+	if isCollectionOptionType(optionType) {
+		if option.Elem, err = structFieldOptionType(field.Type.Elem()); err != nil || option.Elem == Bool {
+			return Option{}, fmt.Errorf("field %s: unsupported field type %s", field.Name, field.Type)
+		}
+		option.Separator = defaultListSeparator
+	}
+	for _, flag := range parts[1:] {
+		switch strings.TrimSpace(flag) {
+		case "required":
+			option.Required = true
+		case "count":
+			if field.Type.Kind() != reflect.Int {
+				return Option{}, fmt.Errorf("field %s: count flag requires int field", field.Name)
+			}
+			option.Type = Count
+		case "indirect":
+			option.Indirect = true
+		case "secret":
+			option.Secret = true
+		case "negatable":
+			if optionType != Bool {
+				return Option{}, fmt.Errorf("field %s: negatable flag requires bool field", field.Name)
+			}
+			option.Negatable = true
+		case "":
+		default:
+			return Option{}, fmt.Errorf("field %s have unknown cmd tag flag '%s'", field.Name, flag)
+		}
+	}
+
+	option.Description = field.Tag.Get(structHelpTag)
+	option.Default = field.Tag.Get(structDefaultTag)
+	option.Env = field.Tag.Get(structEnvTag)
+	if choices, exist := field.Tag.Lookup(structEnumTag); exist {
+		if optionType != String || choices == "" {
+			return Option{}, fmt.Errorf("field %s: enum tag requires string field with choices", field.Name)
+		}
+		option.Type = Enum
+		option.Choices = strings.Split(choices, ",")
+	}
+	if short, exist := field.Tag.Lookup(structShortTag); exist {
+		runes := []rune(short)
+		if len(runes) != 1 || !unicode.IsLetter(runes[0]) {
+			return Option{}, fmt.Errorf("field %s: short tag must be a single letter", field.Name)
+		}
+		option.Short = runes[0]
+	}
+	return option, nil
+}
+
+func structFieldOptionType(t reflect.Type) (OptionType, error) {
+	if !ctx.IsBindable(t) {
+		return 0, fmt.Errorf("unsupported field type %s", t)
+	}
+
+	switch t {
+	case reflect.TypeOf(time.Duration(0)):
+		return Duration, nil
+	case reflect.TypeOf(time.Time{}):
+		return Timestamp, nil
+	case reflect.TypeOf((*url.URL)(nil)):
+		return URL, nil
+	case reflect.TypeOf(netip.Addr{}):
+		return IP, nil
+	case reflect.TypeOf(netip.Prefix{}):
+		return CIDR, nil
+	case reflect.TypeOf(netip.AddrPort{}):
+		return HostPort, nil
+	}
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return String, nil
+	}
+	if t.Kind() == reflect.Slice {
+		return List, nil
+	}
+	if t.Kind() == reflect.Map {
+		return Map, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return Bool, nil
+	case reflect.Int:
+		return Int, nil
+	case reflect.Int8:
+		return Int8, nil
+	case reflect.Int16:
+		return Int16, nil
+	case reflect.Int32:
+		return Int32, nil
+	case reflect.Int64:
+		return Int64, nil
+	case reflect.Uint:
+		return Uint, nil
+	case reflect.Uint8:
+		return Uint8, nil
+	case reflect.Uint16:
+		return Uint16, nil
+	case reflect.Uint32:
+		return Uint32, nil
+	case reflect.Uint64:
+		return Uint64, nil
+	case reflect.Float32, reflect.Float64:
+		return Float, nil
+	}
+	return String, nil
+}
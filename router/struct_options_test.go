@@ -0,0 +1,142 @@
+package router
+
+import (
+	"strings"
+	"testing"
+
+	ctx "github.com/DilemaFixer/Cmd/context"
+)
+
+type copyOptions struct {
+	Source      string  `cmd:"source,required" help:"Source path"`
+	Destination string  `cmd:"destination,required" help:"Destination path"`
+	BufferSize  int     `cmd:"buffer-size" default:"4096" help:"Buffer size in bytes"`
+	Threads     uint8   `cmd:"threads" env:"CMD_TEST_THREADS"`
+	Throttle    float64 `cmd:"throttle"`
+	Verify      bool    `cmd:"verify"`
+	ignored     string
+}
+
+func TestStructOptions_WithTaggedStruct_GeneratesOptions(t *testing.T) {
+	r := NewRouter()
+	w := r.Endpoint("copy").StructOptions(copyOptions{})
+
+	options := w.endpoint.options
+	if len(options) != 6 {
+		t.Fatalf("expected 6 options, got %d", len(options))
+	}
+
+	source := options["source"]
+	if source.Type != String || !source.Required || source.Description != "Source path" {
+		t.Errorf("unexpected source option %+v", source)
+	}
+	if buffer := options["buffer-size"]; buffer.Type != Int || buffer.Required || buffer.Default != "4096" {
+		t.Errorf("unexpected buffer-size option %+v", buffer)
+	}
+	if threads := options["threads"]; threads.Type != Uint8 || threads.Env != "CMD_TEST_THREADS" {
+		t.Errorf("unexpected threads option %+v", threads)
+	}
+	if verify := options["verify"]; verify.Type != Bool {
+		t.Errorf("unexpected verify option %+v", verify)
+	}
+}
+
+func TestStructOptions_WithUnsupportedField_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic for unsupported field type")
+		}
+	}()
+
+	NewRouter().Endpoint("bad").StructOptions(struct {
+		Values chan int `cmd:"values"`
+	}{})
+}
+
+func TestStructHandler_WithDefaultsAndEnv_PopulatesStruct(t *testing.T) {
+	t.Setenv("CMD_TEST_THREADS", "3")
+	c, it := mk("copy --source=/tmp/a --destination=/tmp/b --verify", t)
+	r := NewRouter()
+	r.CustomErrorHandler(func(err error, _ ctx.Context) { t.Fatalf("unexpected error: %v", err) })
+
+	var got *copyOptions
+	r.Endpoint("copy").
+		StructOptions(copyOptions{}).
+		Handler(StructHandler(func(_ ctx.Context, opts *copyOptions) error {
+			got = opts
+			return nil
+		})).
+		Register()
+
+	r.Route(*c, it)
+
+	if got == nil {
+		t.Fatalf("handler was not called")
+	}
+	want := copyOptions{Source: "/tmp/a", Destination: "/tmp/b", BufferSize: 4096, Threads: 3, Verify: true}
+	if *got != want {
+		t.Fatalf("expected %+v, got %+v", want, *got)
+	}
+}
+
+func TestStructHandler_WithMissingRequired_ReturnsError(t *testing.T) {
+	c, it := mk("copy --source=/tmp/a", t)
+	r := NewRouter()
+
+	var gotErr error
+	r.CustomErrorHandler(func(err error, _ ctx.Context) { gotErr = err })
+	r.Endpoint("copy").
+		StructOptions(copyOptions{}).
+		Handler(StructHandler(func(ctx.Context, *copyOptions) error { return nil })).
+		Register()
+
+	r.Route(*c, it)
+
+	if gotErr == nil || !strings.Contains(gotErr.Error(), "destination") {
+		t.Fatalf("expected required destination error, got %v", gotErr)
+	}
+}
+
+func TestHelp_WithStructOptions_RendersDescriptionsAndDefaults(t *testing.T) {
+	w := NewRouter().Endpoint("copy").Description("Copy files").StructOptions(copyOptions{})
+
+	help := w.endpoint.Help()
+	for _, want := range []string{
+		"copy - Copy files",
+		"--source=<string>",
+		"Source path (required)",
+		"Buffer size in bytes (default: 4096)",
+		"(env: CMD_TEST_THREADS)",
+		"--verify",
+	} {
+		if !strings.Contains(help, want) {
+			t.Errorf("help does not contain %q:\n%s", want, help)
+		}
+	}
+}
+
+func TestStructHandler_WithCountAndNegatableTags_PopulatesStruct(t *testing.T) {
+	type buildOptions struct {
+		Verbose int  `cmd:"verbose,count" short:"v"`
+		Cache   bool `cmd:"cache,negatable" default:"true"`
+	}
+
+	c, it := mk("build -vv --no-cache", t)
+	r := NewRouter()
+	r.CustomErrorHandler(func(err error, _ ctx.Context) { t.Fatalf("unexpected error: %v", err) })
+
+	var got *buildOptions
+	r.Endpoint("build").
+		StructOptions(buildOptions{}).
+		Handler(StructHandler(func(_ ctx.Context, opts *buildOptions) error {
+			got = opts
+			return nil
+		})).
+		Register()
+
+	r.Route(*c, it)
+
+	if got == nil || *got != (buildOptions{Verbose: 2, Cache: false}) {
+		t.Fatalf("unexpected options %+v", got)
+	}
+}